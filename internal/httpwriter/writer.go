@@ -0,0 +1,81 @@
+package httpwriter
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"sync/atomic"
+)
+
+// ResponseWriter wraps an http.ResponseWriter to record the status code and
+// body size of a response. The optional http.Flusher, http.Hijacker and
+// http.Pusher interfaces are passed through to the wrapped writer, with
+// http.ErrNotSupported reported where it lacks them.
+type ResponseWriter struct {
+	// Accessed atomically, first for 64-bit alignment on 32-bit platforms
+	written int64
+
+	http.ResponseWriter
+	status int
+}
+
+// New TODO.
+func New(w http.ResponseWriter) *ResponseWriter {
+	return &ResponseWriter{ResponseWriter: w}
+}
+
+// Status returns the status code sent, http.StatusOK if a body was written
+// without one, or zero if nothing has been sent yet.
+func (rw *ResponseWriter) Status() int { return rw.status }
+
+// Written returns the number of body bytes written so far, and is safe to
+// call concurrently with writes.
+func (rw *ResponseWriter) Written() int64 { return atomic.LoadInt64(&rw.written) }
+
+// WriteHeader TODO.
+func (rw *ResponseWriter) WriteHeader(status int) {
+	if rw.status == 0 {
+		rw.status = status
+	}
+	rw.ResponseWriter.WriteHeader(status)
+}
+
+// Write TODO.
+func (rw *ResponseWriter) Write(p []byte) (int, error) {
+	if rw.status == 0 {
+		rw.status = http.StatusOK
+	}
+
+	n, err := rw.ResponseWriter.Write(p)
+	atomic.AddInt64(&rw.written, int64(n))
+	return n, err
+}
+
+// Flush TODO.
+func (rw *ResponseWriter) Flush() {
+	if f, ok := rw.ResponseWriter.(http.Flusher); ok {
+		if rw.status == 0 {
+			rw.status = http.StatusOK
+		}
+		f.Flush()
+	}
+}
+
+// Hijack TODO.
+func (rw *ResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if h, ok := rw.ResponseWriter.(http.Hijacker); ok {
+		return h.Hijack()
+	}
+	return nil, nil, http.ErrNotSupported
+}
+
+// Push TODO.
+func (rw *ResponseWriter) Push(target string, opts *http.PushOptions) error {
+	if p, ok := rw.ResponseWriter.(http.Pusher); ok {
+		return p.Push(target, opts)
+	}
+	return http.ErrNotSupported
+}
+
+// Unwrap returns the wrapped writer.
+func (rw *ResponseWriter) Unwrap() http.ResponseWriter { return rw.ResponseWriter }
@@ -0,0 +1,109 @@
+package httpwriter_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/oligarch316/go-netx/internal/httpwriter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type plainWriter struct{ http.ResponseWriter }
+
+func TestResponseWriter(t *testing.T) {
+	subtests := []struct {
+		name           string
+		write          func(http.ResponseWriter)
+		expectedStatus int
+		expectedBytes  int64
+	}{
+		{
+			name:           "nothing written",
+			write:          func(http.ResponseWriter) {},
+			expectedStatus: 0,
+			expectedBytes:  0,
+		},
+		{
+			name:           "implicit status",
+			write:          func(w http.ResponseWriter) { w.Write([]byte("hello")) },
+			expectedStatus: http.StatusOK,
+			expectedBytes:  5,
+		},
+		{
+			name: "explicit status",
+			write: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusTeapot)
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte("hi"))
+			},
+			expectedStatus: http.StatusTeapot,
+			expectedBytes:  2,
+		},
+		{
+			name:           "flush",
+			write:          func(w http.ResponseWriter) { w.(http.Flusher).Flush() },
+			expectedStatus: http.StatusOK,
+			expectedBytes:  0,
+		},
+	}
+
+	for _, item := range subtests {
+		subtest := item
+
+		t.Run(subtest.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				rec = httptest.NewRecorder()
+				rw  = httpwriter.New(rec)
+			)
+
+			subtest.write(rw)
+			assert.Equal(t, subtest.expectedStatus, rw.Status())
+			assert.Equal(t, subtest.expectedBytes, rw.Written())
+		})
+	}
+}
+
+func TestResponseWriterInterfaces(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	// Passed through where supported
+	httpwriter.New(rec).Flush()
+	assert.True(t, rec.Flushed)
+
+	// Reported as unsupported otherwise
+	rw := httpwriter.New(plainWriter{rec})
+
+	_, _, err := rw.Hijack()
+	assert.ErrorIs(t, err, http.ErrNotSupported)
+	assert.ErrorIs(t, rw.Push("/", nil), http.ErrNotSupported)
+
+	require.NotPanics(t, rw.Flush)
+	assert.Equal(t, plainWriter{rec}, rw.Unwrap())
+}
+
+func TestResponseWriterHijack(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		conn, buf, err := httpwriter.New(w).Hijack()
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		defer conn.Close()
+		buf.WriteString("HTTP/1.1 200 OK\r\nContent-Length: 8\r\nConnection: close\r\n\r\nhijacked")
+		buf.Flush()
+	}))
+	defer svr.Close()
+
+	resp, err := http.Get(svr.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "hijacked", string(body))
+}
@@ -0,0 +1,219 @@
+package limitx
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Rate TODO.
+type Rate struct {
+	// Limit is the sustained number of events per second, zero or less for no
+	// limit.
+	Limit float64
+
+	// Burst is the maximum number of events allowed at once, at least one.
+	Burst int
+}
+
+// Every TODO.
+func Every(interval time.Duration, burst int) Rate {
+	if interval <= 0 {
+		return Rate{Burst: burst}
+	}
+	return Rate{Limit: float64(time.Second) / float64(interval), Burst: burst}
+}
+
+// Unlimited TODO.
+func (r Rate) Unlimited() bool { return r.Limit <= 0 }
+
+func (r Rate) burst() float64 {
+	if r.Burst < 1 {
+		return 1
+	}
+	return float64(r.Burst)
+}
+
+// refillTime is how long an empty bucket takes to refill completely.
+func (r Rate) refillTime() time.Duration {
+	return time.Duration(r.burst() / r.Limit * float64(time.Second))
+}
+
+// Bucket is a token bucket, starting full.
+type Bucket struct {
+	rate Rate
+	now  func() time.Time
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewBucket TODO.
+func NewBucket(rate Rate) *Bucket {
+	return newBucket(rate, time.Now)
+}
+
+func newBucket(rate Rate, now func() time.Time) *Bucket {
+	return &Bucket{rate: rate, now: now, tokens: rate.burst(), last: now()}
+}
+
+// refill must be called with b.mu held.
+func (b *Bucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = math.Min(b.rate.burst(), b.tokens+elapsed.Seconds()*b.rate.Limit)
+		b.last = now
+	}
+}
+
+// Allow takes a token if one is available. Otherwise it reports how long
+// until one will be.
+func (b *Bucket) Allow() (bool, time.Duration) {
+	if b.rate.Unlimited() {
+		return true, 0
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill(b.now())
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+
+	wait := time.Duration((1 - b.tokens) / b.rate.Limit * float64(time.Second))
+	return false, wait
+}
+
+// refund returns a token taken by Allow.
+func (b *Bucket) refund() {
+	if b.rate.Unlimited() {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens = math.Min(b.rate.burst(), b.tokens+1)
+}
+
+// AllowAll takes a token from every bucket only if each has one available, so
+// that a bucket is never drained on behalf of an event another rejects. It
+// otherwise reports the index of the first bucket without a token and how long
+// until that bucket has one.
+func AllowAll(buckets ...*Bucket) (bool, int, time.Duration) {
+	for i, b := range buckets {
+		if ok, wait := b.Allow(); !ok {
+			for _, taken := range buckets[:i] {
+				taken.refund()
+			}
+			return false, i, wait
+		}
+	}
+	return true, -1, 0
+}
+
+// full reports whether the bucket has refilled completely, at which point it
+// is indistinguishable from a new bucket.
+func (b *Bucket) full(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill(now)
+	return b.tokens >= b.rate.burst()
+}
+
+// Keyed maintains an independent bucket per key. Buckets that have refilled
+// completely are discarded periodically, bounding memory to active keys.
+//
+// Where keys are chosen by clients, active keys alone are no bound at all, so
+// that the number of buckets may be capped too. Once the cap is reached, keys
+// without a bucket share a single overflow bucket until sweeping frees room.
+type Keyed struct {
+	rate    Rate
+	maxKeys int
+	now     func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*Bucket
+	overflow  *Bucket
+	sweepSize int
+	lastSweep time.Time
+}
+
+const keyedMinSweepSize = 64
+
+// DefaultMaxKeys TODO.
+const DefaultMaxKeys = 10000
+
+// NewKeyed returns a limiter keeping at most maxKeys buckets, zero or less for
+// no cap.
+func NewKeyed(rate Rate, maxKeys int) *Keyed {
+	return newKeyed(rate, maxKeys, time.Now)
+}
+
+func newKeyed(rate Rate, maxKeys int, now func() time.Time) *Keyed {
+	return &Keyed{
+		rate:      rate,
+		maxKeys:   maxKeys,
+		now:       now,
+		buckets:   make(map[string]*Bucket),
+		sweepSize: keyedMinSweepSize,
+	}
+}
+
+// Len TODO.
+func (k *Keyed) Len() int {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return len(k.buckets)
+}
+
+func (k *Keyed) lookup(key string) *Bucket {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if res, ok := k.buckets[key]; ok {
+		return res
+	}
+
+	var (
+		now    = k.now()
+		capped = k.maxKeys > 0 && len(k.buckets) >= k.maxKeys
+	)
+
+	// Sweeping at the cap is limited to once per refill, lest every request
+	// with a new key pay for a sweep that can free nothing
+	if len(k.buckets) >= k.sweepSize || (capped && now.Sub(k.lastSweep) >= k.rate.refillTime()) {
+		for bKey, bucket := range k.buckets {
+			if bucket.full(now) {
+				delete(k.buckets, bKey)
+			}
+		}
+
+		if k.sweepSize = 2 * len(k.buckets); k.sweepSize < keyedMinSweepSize {
+			k.sweepSize = keyedMinSweepSize
+		}
+		k.lastSweep = now
+	}
+
+	if k.maxKeys > 0 && len(k.buckets) >= k.maxKeys {
+		if k.overflow == nil {
+			k.overflow = newBucket(k.rate, k.now)
+		}
+		return k.overflow
+	}
+
+	res := newBucket(k.rate, k.now)
+	k.buckets[key] = res
+	return res
+}
+
+// Allow TODO.
+func (k *Keyed) Allow(key string) (bool, time.Duration) {
+	if k.rate.Unlimited() {
+		return true, 0
+	}
+	return k.lookup(key).Allow()
+}
@@ -0,0 +1,140 @@
+package limitx
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testClock struct{ time.Time }
+
+func (tc *testClock) Now() time.Time          { return tc.Time }
+func (tc *testClock) Advance(d time.Duration) { tc.Time = tc.Time.Add(d) }
+
+func TestBucket(t *testing.T) {
+	var (
+		clock = &testClock{Time: time.Unix(0, 0)}
+		b     = newBucket(Rate{Limit: 2, Burst: 3}, clock.Now)
+	)
+
+	// Full burst available up front
+	for i := 0; i < 3; i++ {
+		ok, _ := b.Allow()
+		assert.True(t, ok, "burst %d", i)
+	}
+
+	ok, wait := b.Allow()
+	assert.False(t, ok)
+	assert.Equal(t, 500*time.Millisecond, wait)
+
+	clock.Advance(250 * time.Millisecond)
+	ok, wait = b.Allow()
+	assert.False(t, ok)
+	assert.Equal(t, 250*time.Millisecond, wait)
+
+	clock.Advance(250 * time.Millisecond)
+	ok, _ = b.Allow()
+	assert.True(t, ok)
+
+	// Refill is capped at the burst size
+	clock.Advance(time.Hour)
+	for i := 0; i < 3; i++ {
+		ok, _ = b.Allow()
+		assert.True(t, ok, "refilled burst %d", i)
+	}
+	ok, _ = b.Allow()
+	assert.False(t, ok)
+}
+
+func TestBucketUnlimited(t *testing.T) {
+	b := NewBucket(Rate{})
+	for i := 0; i < 100; i++ {
+		ok, _ := b.Allow()
+		assert.True(t, ok)
+	}
+}
+
+func TestKeyed(t *testing.T) {
+	var (
+		clock = &testClock{Time: time.Unix(0, 0)}
+		k     = newKeyed(Every(time.Second, 1), 0, clock.Now)
+	)
+
+	ok, _ := k.Allow("a")
+	assert.True(t, ok)
+	ok, _ = k.Allow("a")
+	assert.False(t, ok)
+	ok, _ = k.Allow("b")
+	assert.True(t, ok, "keys are independent")
+
+	// Refilled buckets are swept once enough keys accumulate
+	for i := 0; i < keyedMinSweepSize-2; i++ {
+		k.Allow(fmt.Sprintf("key-%d", i))
+	}
+	assert.Equal(t, keyedMinSweepSize, k.Len())
+
+	clock.Advance(time.Second)
+	k.Allow("c")
+	assert.Equal(t, 1, k.Len())
+}
+
+func TestAllowAll(t *testing.T) {
+	var (
+		clock  = &testClock{Time: time.Unix(0, 0)}
+		first  = newBucket(Every(time.Second, 1), clock.Now)
+		second = newBucket(Every(2*time.Second, 1), clock.Now)
+	)
+
+	ok, idx, _ := AllowAll(first, second)
+	assert.True(t, ok)
+	assert.Equal(t, -1, idx)
+
+	// Both empty, the first reports
+	ok, idx, wait := AllowAll(first, second)
+	assert.False(t, ok)
+	assert.Equal(t, 0, idx)
+	assert.Equal(t, time.Second, wait)
+
+	// Only the first refilled, its token is left in place
+	clock.Advance(time.Second)
+	ok, idx, wait = AllowAll(first, second)
+	assert.False(t, ok)
+	assert.Equal(t, 1, idx)
+	assert.Equal(t, time.Second, wait)
+
+	ok, _ = first.Allow()
+	assert.True(t, ok, "expected token refunded")
+
+	clock.Advance(time.Second)
+	ok, _, _ = AllowAll(first, second)
+	assert.True(t, ok)
+}
+
+func TestKeyedMaxKeys(t *testing.T) {
+	const maxKeys = 4
+
+	var (
+		clock = &testClock{Time: time.Unix(0, 0)}
+		k     = newKeyed(Every(time.Second, 1), maxKeys, clock.Now)
+	)
+
+	for i := 0; i < maxKeys; i++ {
+		ok, _ := k.Allow(fmt.Sprintf("key-%d", i))
+		assert.True(t, ok)
+	}
+
+	// Beyond the cap, new keys share a single bucket
+	ok, _ := k.Allow("overflow-a")
+	assert.True(t, ok)
+	ok, _ = k.Allow("overflow-b")
+	assert.False(t, ok)
+	assert.Equal(t, maxKeys, k.Len())
+
+	// Refilled buckets are swept to make room
+	clock.Advance(time.Second)
+	ok, _ = k.Allow("new")
+	assert.True(t, ok)
+	assert.Equal(t, 1, k.Len())
+}
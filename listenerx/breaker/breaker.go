@@ -0,0 +1,204 @@
+package breaker
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/oligarch316/go-netx/listenerx/multi"
+	"github.com/oligarch316/go-netx/listenerx/retry"
+)
+
+var errCircuitOpen = errors.New("breaker: circuit open")
+
+// State TODO.
+type State int
+
+const (
+	// StateClosed TODO.
+	StateClosed State = iota
+
+	// StateOpen TODO.
+	StateOpen
+
+	// StateHalfOpen TODO.
+	StateHalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("unknown state %d", int(s))
+	}
+}
+
+// StateEvent TODO.
+type StateEvent struct {
+	Key      string
+	From, To State
+
+	// CoolDown is only meaningful when To is StateOpen.
+	CoolDown time.Duration
+}
+
+// EventHandler TODO.
+type EventHandler func(StateEvent)
+
+// Option TODO.
+type Option func(*Params)
+
+// Params TODO.
+type Params struct {
+	FailureThreshold int
+	SuccessThreshold int
+	CoolDown         retry.DelayFunc
+	EventHandler     EventHandler
+	Strategy         multi.DialStrategy
+}
+
+func defaultParams() Params {
+	return Params{
+		FailureThreshold: 5,
+		SuccessThreshold: 1,
+		CoolDown:         retry.DelayFuncExponential(100*time.Millisecond, 30*time.Second, 2),
+		EventHandler:     func(StateEvent) {},
+		Strategy:         multi.DialStrategyIterative,
+	}
+}
+
+func buildParams(opts []Option) Params {
+	res := defaultParams()
+	for _, opt := range opts {
+		opt(&res)
+	}
+	return res
+}
+
+// Breaker TODO.
+type Breaker struct {
+	params Params
+	key    string
+	now    func() time.Time
+
+	mu        sync.Mutex
+	state     State
+	failures  int
+	successes int
+	probing   bool
+	openUntil time.Time
+	coolDown  *retry.Delay
+}
+
+// New TODO.
+func New(key string, opts ...Option) *Breaker { return newBreaker(key, buildParams(opts)) }
+
+func newBreaker(key string, params Params) *Breaker {
+	return &Breaker{
+		params:   params,
+		key:      key,
+		now:      time.Now,
+		coolDown: retry.NewDelay(params.CoolDown),
+	}
+}
+
+// State TODO.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// transition must be called with b.mu held. The event handler is returned
+// rather than invoked so that it runs after the lock is released.
+func (b *Breaker) transition(to State) func() {
+	event := StateEvent{Key: b.key, From: b.state, To: to}
+
+	switch to {
+	case StateClosed:
+		b.coolDown.Reset()
+	case StateOpen:
+		_, event.CoolDown = b.coolDown.Next()
+		b.openUntil = b.now().Add(event.CoolDown)
+	}
+
+	b.state, b.failures, b.successes, b.probing = to, 0, 0, false
+	return func() { b.params.EventHandler(event) }
+}
+
+// Allow TODO.
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+
+	var notify func()
+
+	switch b.state {
+	case StateOpen:
+		if b.now().Before(b.openUntil) {
+			b.mu.Unlock()
+			return fmt.Errorf("%w: %s", errCircuitOpen, b.key)
+		}
+		notify = b.transition(StateHalfOpen)
+		fallthrough
+	case StateHalfOpen:
+		// Only a single probe is permitted in flight while half-open
+		if b.probing {
+			b.mu.Unlock()
+			return fmt.Errorf("%w: %s (probing)", errCircuitOpen, b.key)
+		}
+		b.probing = true
+	}
+
+	b.mu.Unlock()
+
+	if notify != nil {
+		notify()
+	}
+	return nil
+}
+
+// Done TODO.
+func (b *Breaker) Done(success bool) {
+	b.mu.Lock()
+
+	var notify func()
+
+	switch {
+	case b.state == StateClosed && success:
+		b.failures = 0
+	case b.state == StateClosed:
+		if b.failures++; b.failures >= b.params.FailureThreshold {
+			notify = b.transition(StateOpen)
+		}
+	case b.state == StateHalfOpen && success:
+		b.probing = false
+		if b.successes++; b.successes >= b.params.SuccessThreshold {
+			notify = b.transition(StateClosed)
+		}
+	case b.state == StateHalfOpen:
+		notify = b.transition(StateOpen)
+	}
+
+	b.mu.Unlock()
+
+	if notify != nil {
+		notify()
+	}
+}
+
+// release relinquishes a half-open probe without recording an outcome, for use
+// when a dial was abandoned by the caller rather than failed by the target.
+func (b *Breaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateHalfOpen {
+		b.probing = false
+	}
+}
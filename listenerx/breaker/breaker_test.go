@@ -0,0 +1,78 @@
+package breaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/oligarch316/go-netx/listenerx/retry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testClock struct{ time.Time }
+
+func (tc *testClock) Now() time.Time          { return tc.Time }
+func (tc *testClock) Advance(d time.Duration) { tc.Time = tc.Time.Add(d) }
+func (tc *testClock) breaker(opts ...Option) *Breaker {
+	res := New("test", opts...)
+	res.now = tc.Now
+	return res
+}
+
+func TestBreakerTransitions(t *testing.T) {
+	var (
+		clock  = &testClock{Time: time.Unix(0, 0)}
+		events []StateEvent
+	)
+
+	b := clock.breaker(
+		WithFailureThreshold(2),
+		WithSuccessThreshold(2),
+		WithCoolDown(retry.DelayFuncMultiplicative(time.Second, time.Minute, 1)),
+		WithEventHandler(func(e StateEvent) { events = append(events, e) }),
+	)
+
+	// Closed => open after consecutive failures
+	require.NoError(t, b.Allow())
+	b.Done(false)
+	require.NoError(t, b.Allow())
+	b.Done(false)
+	assert.Equal(t, StateOpen, b.State())
+
+	// Open => rejected until cool down elapses
+	assert.ErrorIs(t, b.Allow(), errCircuitOpen)
+	clock.Advance(time.Second)
+
+	// Half-open => single probe in flight, failure re-opens with longer cool down
+	require.NoError(t, b.Allow())
+	assert.Equal(t, StateHalfOpen, b.State())
+	assert.ErrorIs(t, b.Allow(), errCircuitOpen)
+	b.Done(false)
+	assert.Equal(t, StateOpen, b.State())
+
+	// Half-open => closed after consecutive probe successes
+	clock.Advance(2 * time.Second)
+	require.NoError(t, b.Allow())
+	b.Done(true)
+	require.NoError(t, b.Allow())
+	b.Done(true)
+	assert.Equal(t, StateClosed, b.State())
+
+	assert.Equal(t, []StateEvent{
+		{Key: "test", From: StateClosed, To: StateOpen, CoolDown: time.Second},
+		{Key: "test", From: StateOpen, To: StateHalfOpen},
+		{Key: "test", From: StateHalfOpen, To: StateOpen, CoolDown: 2 * time.Second},
+		{Key: "test", From: StateOpen, To: StateHalfOpen},
+		{Key: "test", From: StateHalfOpen, To: StateClosed},
+	}, events)
+}
+
+func TestBreakerClosedSuccessResets(t *testing.T) {
+	b := (&testClock{}).breaker(WithFailureThreshold(2))
+
+	b.Done(false)
+	b.Done(true)
+	b.Done(false)
+
+	assert.Equal(t, StateClosed, b.State())
+}
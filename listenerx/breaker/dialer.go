@@ -0,0 +1,117 @@
+package breaker
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	"github.com/oligarch316/go-netx"
+	"github.com/oligarch316/go-netx/listenerx/multi"
+)
+
+func (b *Breaker) dialContext(ctx context.Context, dial func(context.Context) (net.Conn, error)) (net.Conn, error) {
+	if err := b.Allow(); err != nil {
+		return nil, err
+	}
+
+	res, err := dial(ctx)
+
+	switch {
+	case err == nil:
+		b.Done(true)
+	case ctx.Err() != nil:
+		// Abandoned by the caller, not a failure of the target
+		b.release()
+	default:
+		b.Done(false)
+	}
+
+	return res, err
+}
+
+// Dialer TODO.
+type Dialer struct {
+	dialer  netx.Dialer
+	breaker *Breaker
+}
+
+// NewDialer TODO.
+func NewDialer(key string, dialer netx.Dialer, opts ...Option) *Dialer {
+	return &Dialer{dialer: dialer, breaker: New(key, opts...)}
+}
+
+// State TODO.
+func (d *Dialer) State() State { return d.breaker.State() }
+
+// Dial TODO.
+func (d *Dialer) Dial() (net.Conn, error) {
+	return d.DialContext(context.Background())
+}
+
+// DialContext TODO.
+func (d *Dialer) DialContext(ctx context.Context) (net.Conn, error) {
+	return d.breaker.dialContext(ctx, d.dialer.DialContext)
+}
+
+// SetDialer TODO.
+type SetDialer struct {
+	params Params
+	dialer *multi.Dialer
+
+	mu       sync.Mutex
+	breakers map[string]*Breaker
+}
+
+// NewSetDialer TODO.
+func NewSetDialer(dialer *multi.Dialer, opts ...Option) *SetDialer {
+	return &SetDialer{
+		params:   buildParams(opts),
+		dialer:   dialer,
+		breakers: make(map[string]*Breaker),
+	}
+}
+
+func (sd *SetDialer) lookup(hash multi.SetHash) *Breaker {
+	key := hash.HashString()
+
+	sd.mu.Lock()
+	defer sd.mu.Unlock()
+
+	res, ok := sd.breakers[key]
+	if !ok {
+		res = newBreaker(key, sd.params)
+		sd.breakers[key] = res
+	}
+	return res
+}
+
+// State TODO.
+func (sd *SetDialer) State(hash multi.SetHash) State { return sd.lookup(hash).State() }
+
+// Len TODO.
+func (sd *SetDialer) Len() int { return sd.dialer.Len() }
+
+// Resolve TODO.
+func (sd *SetDialer) Resolve() []multi.SetAddr { return sd.dialer.Resolve() }
+
+// DialHash TODO.
+func (sd *SetDialer) DialHash(hash multi.SetHash) (net.Conn, error) {
+	return sd.DialContextHash(context.Background(), hash)
+}
+
+// DialContextHash TODO.
+func (sd *SetDialer) DialContextHash(ctx context.Context, hash multi.SetHash) (net.Conn, error) {
+	return sd.lookup(hash).dialContext(ctx, func(ctx context.Context) (net.Conn, error) {
+		return sd.dialer.DialContextHash(ctx, hash)
+	})
+}
+
+// Dial TODO.
+func (sd *SetDialer) Dial() (net.Conn, error) {
+	return sd.DialContext(context.Background())
+}
+
+// DialContext TODO.
+func (sd *SetDialer) DialContext(ctx context.Context) (net.Conn, error) {
+	return sd.params.Strategy(ctx, sd.Resolve(), sd.DialContextHash)
+}
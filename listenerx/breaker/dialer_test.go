@@ -0,0 +1,139 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/oligarch316/go-netx"
+	"github.com/oligarch316/go-netx/listenerx"
+	"github.com/oligarch316/go-netx/listenerx/multi"
+	"github.com/oligarch316/go-netx/listenerx/retry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errTestDial = errors.New("test dial failure")
+
+type testDialer struct {
+	err   error
+	calls int
+}
+
+func (td *testDialer) Dial() (net.Conn, error) { return td.DialContext(context.Background()) }
+
+func (td *testDialer) DialContext(ctx context.Context) (net.Conn, error) {
+	td.calls++
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if td.err != nil {
+		return nil, td.err
+	}
+
+	client, server := net.Pipe()
+	server.Close()
+	return client, nil
+}
+
+func TestDialer(t *testing.T) {
+	var (
+		target = &testDialer{err: errTestDial}
+		dialer = NewDialer("test", target,
+			WithFailureThreshold(2),
+			WithCoolDown(retry.DelayFuncConstant(time.Hour)),
+		)
+	)
+
+	// Failures open the circuit
+	for i := 0; i < 2; i++ {
+		_, err := dialer.Dial()
+		assert.ErrorIs(t, err, errTestDial)
+	}
+
+	assert.Equal(t, StateOpen, dialer.State())
+
+	// Open circuit rejects without reaching the target
+	_, err := dialer.Dial()
+	assert.ErrorIs(t, err, errCircuitOpen)
+	assert.Equal(t, 2, target.calls)
+}
+
+func TestDialerCanceled(t *testing.T) {
+	var (
+		target = &testDialer{}
+		dialer = NewDialer("test", target, WithFailureThreshold(1))
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Abandoned dials are not failures of the target
+	_, err := dialer.DialContext(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, StateClosed, dialer.State())
+
+	conn, err := dialer.Dial()
+	require.NoError(t, err)
+	conn.Close()
+}
+
+func TestSetDialer(t *testing.T) {
+	var (
+		down = listenerx.NewInternal(0)
+		up   = listenerx.NewInternal(0)
+		ml   = multi.NewListener([]netx.Listener{down, up})
+	)
+
+	defer up.Close()
+	down.Close()
+
+	go func() {
+		for {
+			conn, err := up.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	var downHash, upHash multi.SetHash
+	for _, addr := range ml.Dialer.Resolve() {
+		switch addr.String() {
+		case down.Addr().String():
+			downHash = addr.SetHash
+		case up.Addr().String():
+			upHash = addr.SetHash
+		}
+	}
+
+	require.NotNil(t, downHash)
+	require.NotNil(t, upHash)
+
+	dialer := NewSetDialer(ml.Dialer,
+		WithFailureThreshold(1),
+		WithCoolDown(retry.DelayFuncConstant(time.Hour)),
+	)
+
+	// Each member of the set has an independent breaker
+	for i := 0; i < 2; i++ {
+		conn, err := dialer.Dial()
+		require.NoError(t, err)
+		conn.Close()
+
+		assert.Equal(t, StateOpen, dialer.State(downHash))
+		assert.Equal(t, StateClosed, dialer.State(upHash))
+	}
+
+	_, err := dialer.DialHash(downHash)
+	assert.ErrorIs(t, err, errCircuitOpen)
+
+	conn, err := dialer.DialHash(upHash)
+	require.NoError(t, err)
+	conn.Close()
+}
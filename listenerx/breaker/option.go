@@ -0,0 +1,31 @@
+package breaker
+
+import (
+	"github.com/oligarch316/go-netx/listenerx/multi"
+	"github.com/oligarch316/go-netx/listenerx/retry"
+)
+
+// WithFailureThreshold TODO.
+func WithFailureThreshold(n int) Option {
+	return func(p *Params) { p.FailureThreshold = n }
+}
+
+// WithSuccessThreshold TODO.
+func WithSuccessThreshold(n int) Option {
+	return func(p *Params) { p.SuccessThreshold = n }
+}
+
+// WithCoolDown TODO.
+func WithCoolDown(delayFunc retry.DelayFunc) Option {
+	return func(p *Params) { p.CoolDown = delayFunc }
+}
+
+// WithEventHandler TODO.
+func WithEventHandler(handler EventHandler) Option {
+	return func(p *Params) { p.EventHandler = handler }
+}
+
+// WithStrategy TODO.
+func WithStrategy(strategy multi.DialStrategy) Option {
+	return func(p *Params) { p.Strategy = strategy }
+}
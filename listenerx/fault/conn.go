@@ -0,0 +1,106 @@
+package fault
+
+import (
+	"io"
+	"net"
+	"sync"
+	"syscall"
+	"time"
+)
+
+type conn struct {
+	net.Conn
+	injector *Injector
+
+	// Delayed writes give up once the connection or its write side is closed,
+	// or its write deadline passes, as a blocked write would
+	mu            sync.Mutex
+	writeDeadline time.Time
+	closeChan     chan struct{}
+	closeOnce     sync.Once
+}
+
+// Conn wraps c to inject latency, bandwidth caps, resets and partial writes.
+func (i *Injector) Conn(c net.Conn) net.Conn {
+	return &conn{Conn: c, injector: i, closeChan: make(chan struct{})}
+}
+
+func (c *conn) Close() error {
+	c.closeOnce.Do(func() { close(c.closeChan) })
+	return c.Conn.Close()
+}
+
+// CloseWrite shuts down the writing side of the wrapped connection, where
+// supported, stopping any delayed write.
+func (c *conn) CloseWrite() error {
+	cw, ok := c.Conn.(interface{ CloseWrite() error })
+	if !ok {
+		return errCloseWriteUnsupported
+	}
+
+	c.closeOnce.Do(func() { close(c.closeChan) })
+	return cw.CloseWrite()
+}
+
+func (c *conn) SetDeadline(t time.Time) error {
+	c.mu.Lock()
+	c.writeDeadline = t
+	c.mu.Unlock()
+	return c.Conn.SetDeadline(t)
+}
+
+func (c *conn) SetWriteDeadline(t time.Time) error {
+	c.mu.Lock()
+	c.writeDeadline = t
+	c.mu.Unlock()
+	return c.Conn.SetWriteDeadline(t)
+}
+
+func (c *conn) reset(op string) error {
+	// Discard unsent data so that TCP peers observe a reset as well
+	if tcp, ok := c.Conn.(*net.TCPConn); ok {
+		tcp.SetLinger(0)
+	}
+
+	c.Close()
+	return faultError{op: op, cause: syscall.ECONNRESET}
+}
+
+func (c *conn) Read(p []byte) (int, error) {
+	if c.injector.roll(c.injector.params.ResetRate) {
+		return 0, c.reset("read")
+	}
+	return c.Conn.Read(p)
+}
+
+func (c *conn) Write(p []byte) (int, error) {
+	if c.injector.roll(c.injector.params.ResetRate) {
+		return 0, c.reset("write")
+	}
+
+	data, partial := p, false
+	if len(p) > 1 && c.injector.roll(c.injector.params.PartialWriteRate) {
+		data, partial = p[:1+c.injector.intn(len(p)-1)], true
+	}
+
+	c.mu.Lock()
+	deadline := c.writeDeadline
+	c.mu.Unlock()
+
+	switch err := c.injector.delay(len(data), c.closeChan, deadline); err {
+	case nil:
+	case errDelayStopped:
+		return 0, net.ErrClosed
+	default:
+		return 0, err
+	}
+
+	n, err := c.Conn.Write(data)
+	if err == nil && partial {
+		err = faultError{op: "write", cause: io.ErrShortWrite}
+	}
+	return n, err
+}
+
+// NetConn TODO.
+func (c *conn) NetConn() net.Conn { return c.Conn }
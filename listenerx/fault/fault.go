@@ -0,0 +1,167 @@
+package fault
+
+import (
+	"errors"
+	"math/rand"
+	"os"
+	"sync"
+	"syscall"
+	"time"
+)
+
+var (
+	errInjected     = errors.New("fault: injected")
+	errDelayStopped = errors.New("fault: delay stopped")
+
+	errCloseWriteUnsupported = errors.New("fault: close write not supported")
+)
+
+// faultError is returned for every injected failure. It matches both
+// IsInjected and the errno it simulates, and implements net.Error so that
+// temporary failures are retried by callers such as multi.MergeRunner.
+type faultError struct {
+	op        string
+	cause     error
+	temporary bool
+}
+
+func (fe faultError) Error() string   { return "fault: injected " + fe.op + ": " + fe.cause.Error() }
+func (fe faultError) Unwrap() error   { return fe.cause }
+func (fe faultError) Timeout() bool   { return false }
+func (fe faultError) Temporary() bool { return fe.temporary }
+
+func (fe faultError) Is(target error) bool { return target == errInjected }
+
+// IsInjected reports whether err results from an injected fault.
+func IsInjected(err error) bool { return errors.Is(err, errInjected) }
+
+// Option TODO.
+type Option func(*Params)
+
+// Params TODO.
+//
+// Rates are probabilities in [0, 1] evaluated independently per operation.
+type Params struct {
+	// Seed makes the sequence of injected faults reproducible for a given
+	// sequence of operations.
+	Seed int64
+
+	// AcceptErrorRate fails Accept with a temporary error, without consuming
+	// a connection.
+	AcceptErrorRate float64
+
+	// DialErrorRate fails Dial with a connection refused error.
+	DialErrorRate float64
+
+	// Latency delays every dial and write. Reads are not delayed, so latency
+	// is observed by the reading peer rather than added in both directions.
+	Latency time.Duration
+
+	// Bandwidth caps writes to the given bytes per second, zero for none.
+	// Reads are not capped, wrap both ends to cap both directions.
+	Bandwidth int64
+
+	// ResetRate closes the connection during a read or write, failing it with
+	// a connection reset error.
+	ResetRate float64
+
+	// PartialWriteRate writes only a prefix of the data, failing the write
+	// with io.ErrShortWrite.
+	PartialWriteRate float64
+}
+
+// Injector decides which faults occur, drawing from a single seeded source
+// shared by every listener, dialer and connection it wraps.
+type Injector struct {
+	params Params
+
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+// New TODO.
+func New(opts ...Option) *Injector {
+	var params Params
+	for _, opt := range opts {
+		opt(&params)
+	}
+
+	return &Injector{params: params, rng: rand.New(rand.NewSource(params.Seed))}
+}
+
+// Params TODO.
+func (i *Injector) Params() Params { return i.params }
+
+// roll reports whether an event of the given probability occurs. A zero rate
+// consumes no randomness, so that enabling one fault does not perturb the
+// sequence of another.
+func (i *Injector) roll(rate float64) bool {
+	if rate <= 0 {
+		return false
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.rng.Float64() < rate
+}
+
+// intn returns a number in [0, n).
+func (i *Injector) intn(n int) int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.rng.Intn(n)
+}
+
+func (i *Injector) acceptError() error {
+	if i.roll(i.params.AcceptErrorRate) {
+		return faultError{op: "accept", cause: syscall.ECONNABORTED, temporary: true}
+	}
+	return nil
+}
+
+func (i *Injector) dialError() error {
+	if i.roll(i.params.DialErrorRate) {
+		return faultError{op: "dial", cause: syscall.ECONNREFUSED}
+	}
+	return nil
+}
+
+// delay waits out the latency and bandwidth cost of an n byte operation. It
+// returns errDelayStopped once stop is closed, or os.ErrDeadlineExceeded once a
+// non-zero deadline passes, rather than waiting any further.
+func (i *Injector) delay(n int, stop <-chan struct{}, deadline time.Time) error {
+	d := i.params.Latency
+	if i.params.Bandwidth > 0 {
+		d += time.Duration(int64(n) * int64(time.Second) / i.params.Bandwidth)
+	}
+
+	if d <= 0 {
+		return nil
+	}
+
+	var deadlineC <-chan time.Time
+	if !deadline.IsZero() {
+		until := time.Until(deadline)
+		if until <= 0 {
+			return os.ErrDeadlineExceeded
+		}
+
+		if until < d {
+			deadlineTimer := time.NewTimer(until)
+			defer deadlineTimer.Stop()
+			deadlineC = deadlineTimer.C
+		}
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-deadlineC:
+		return os.ErrDeadlineExceeded
+	case <-stop:
+		return errDelayStopped
+	}
+}
@@ -0,0 +1,303 @@
+package fault_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/oligarch316/go-netx"
+	"github.com/oligarch316/go-netx/listenerx"
+	"github.com/oligarch316/go-netx/listenerx/fault"
+	"github.com/oligarch316/go-netx/listenerx/multi"
+	"github.com/oligarch316/go-netx/listenerx/retry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func dialPattern(t *testing.T, seed int64) []bool {
+	l := listenerx.NewInternal(0)
+	defer l.Close()
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	d := fault.NewDialer(l, fault.WithSeed(seed), fault.WithDialErrorRate(0.5))
+
+	var res []bool
+	for i := 0; i < 32; i++ {
+		conn, err := d.Dial()
+		if err != nil {
+			assert.True(t, fault.IsInjected(err))
+			assert.ErrorIs(t, err, syscall.ECONNREFUSED)
+			res = append(res, false)
+			continue
+		}
+
+		conn.Close()
+		res = append(res, true)
+	}
+
+	return res
+}
+
+func TestSeedReproducible(t *testing.T) {
+	var (
+		a = dialPattern(t, 42)
+		b = dialPattern(t, 42)
+		c = dialPattern(t, 7)
+	)
+
+	assert.Equal(t, a, b, "expected identical faults for identical seeds")
+	assert.NotEqual(t, a, c, "expected different faults for different seeds")
+	assert.Contains(t, a, true)
+	assert.Contains(t, a, false)
+}
+
+func TestAcceptErrorsRetried(t *testing.T) {
+	var (
+		source = fault.NewListener(listenerx.NewInternal(0), fault.WithSeed(1), fault.WithAcceptErrorRate(0.5))
+		events = make(chan multi.RunnerEvent, 100)
+	)
+
+	ml := multi.NewListener(
+		[]netx.Listener{source},
+		multi.WithRunnerRetryDelay(retry.DelayFuncConstant(time.Millisecond)),
+		multi.WithRunnerEventHandler(func(re multi.RunnerEvent) { events <- re }),
+	)
+
+	for _, runner := range ml.Runners() {
+		go runner.Run()
+		defer runner.Close(context.Background())
+	}
+
+	// Injected accept errors must not lose connections
+	for i := 0; i < 10; i++ {
+		conn, err := source.Dial()
+		require.NoError(t, err)
+		defer conn.Close()
+
+		accepted, err := ml.Accept()
+		require.NoError(t, err)
+		accepted.Close()
+	}
+
+	require.NotEmpty(t, events)
+	for len(events) > 0 {
+		event := <-events
+		require.IsType(t, multi.RunnerEventTemporaryAcceptError{}, event)
+		assert.True(t, fault.IsInjected(event))
+	}
+}
+
+func TestConnFaults(t *testing.T) {
+	subtests := []struct {
+		name   string
+		opt    fault.Option
+		verify func(t *testing.T, n int, err error, peerErr error)
+	}{
+		{
+			name: "partial write",
+			opt:  fault.WithPartialWriteRate(1),
+			verify: func(t *testing.T, n int, err, peerErr error) {
+				assert.ErrorIs(t, err, io.ErrShortWrite)
+				assert.True(t, n > 0 && n < 8, "expected partial write, got %d bytes", n)
+				assert.NoError(t, peerErr)
+			},
+		},
+		{
+			name: "reset",
+			opt:  fault.WithResetRate(1),
+			verify: func(t *testing.T, n int, err, peerErr error) {
+				assert.ErrorIs(t, err, syscall.ECONNRESET)
+				assert.Equal(t, 0, n)
+				assert.ErrorIs(t, peerErr, io.EOF)
+			},
+		},
+	}
+
+	for _, subtest := range subtests {
+		subtest := subtest
+
+		t.Run(subtest.name, func(t *testing.T) {
+			t.Parallel()
+
+			l := listenerx.NewInternal(0)
+			defer l.Close()
+
+			peerErr := make(chan error, 1)
+			go func() {
+				conn, err := l.Accept()
+				if err != nil {
+					peerErr <- err
+					return
+				}
+				defer conn.Close()
+
+				_, err = conn.Read(make([]byte, 8))
+				peerErr <- err
+			}()
+
+			conn, err := fault.NewDialer(l, subtest.opt).Dial()
+			require.NoError(t, err)
+			defer conn.Close()
+
+			n, err := conn.Write([]byte("12345678"))
+			assert.True(t, fault.IsInjected(err))
+			subtest.verify(t, n, err, <-peerErr)
+		})
+	}
+}
+
+func TestLatencyAndBandwidth(t *testing.T) {
+	l := listenerx.NewInternal(0)
+	defer l.Close()
+
+	go func() {
+		conn, err := l.Accept()
+		if err == nil {
+			io.Copy(io.Discard, conn)
+		}
+	}()
+
+	conn, err := fault.NewDialer(l, fault.WithLatency(10*time.Millisecond), fault.WithBandwidth(1000)).Dial()
+	require.NoError(t, err)
+	defer conn.Close()
+
+	start := time.Now()
+	_, err = conn.Write(make([]byte, 50))
+	require.NoError(t, err)
+
+	// 10ms latency plus 50 bytes at 1000 bytes per second
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64(60*time.Millisecond))
+}
+
+func TestLatencyInterrupted(t *testing.T) {
+	const latency = time.Hour
+
+	subtests := []struct {
+		name      string
+		interrupt func(net.Conn)
+		expected  error
+	}{
+		{
+			name:      "write deadline",
+			interrupt: func(conn net.Conn) { conn.SetWriteDeadline(time.Now().Add(10 * time.Millisecond)) },
+			expected:  os.ErrDeadlineExceeded,
+		},
+		{
+			name:      "deadline",
+			interrupt: func(conn net.Conn) { conn.SetDeadline(time.Now().Add(10 * time.Millisecond)) },
+			expected:  os.ErrDeadlineExceeded,
+		},
+		{
+			name:      "close",
+			interrupt: func(conn net.Conn) { time.AfterFunc(10*time.Millisecond, func() { conn.Close() }) },
+			expected:  net.ErrClosed,
+		},
+		{
+			name: "close write",
+			interrupt: func(conn net.Conn) {
+				cw := conn.(interface{ CloseWrite() error })
+				time.AfterFunc(10*time.Millisecond, func() { cw.CloseWrite() })
+			},
+			expected: net.ErrClosed,
+		},
+	}
+
+	for _, subtest := range subtests {
+		subtest := subtest
+
+		t.Run(subtest.name, func(t *testing.T) {
+			t.Parallel()
+
+			l := listenerx.NewInternal(0)
+			defer l.Close()
+
+			go func() {
+				conn, err := l.Accept()
+				if err == nil {
+					io.Copy(io.Discard, conn)
+				}
+			}()
+
+			// Latency applies to writes only once dialed
+			conn, err := l.Dial()
+			require.NoError(t, err)
+
+			conn = fault.New(fault.WithLatency(latency)).Conn(conn)
+			defer conn.Close()
+
+			subtest.interrupt(conn)
+
+			start := time.Now()
+			n, err := conn.Write([]byte("12345678"))
+			assert.Equal(t, 0, n)
+			assert.ErrorIs(t, err, subtest.expected)
+			assert.Less(t, int64(time.Since(start)), int64(time.Second))
+		})
+	}
+
+	t.Run("dial context", func(t *testing.T) {
+		t.Parallel()
+
+		l := listenerx.NewInternal(0)
+		defer l.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		_, err := fault.NewDialer(l, fault.WithLatency(latency)).DialContext(ctx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, int64(time.Since(start)), int64(time.Second))
+	})
+}
+
+func TestConnCloseWrite(t *testing.T) {
+	l := listenerx.NewInternal(0)
+	defer l.Close()
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		data, _ := io.ReadAll(conn)
+		received <- string(data)
+		conn.Write([]byte("bye"))
+	}()
+
+	conn, err := l.Dial()
+	require.NoError(t, err)
+
+	conn = fault.New().Conn(conn)
+	defer conn.Close()
+
+	_, err = conn.Write([]byte("hello"))
+	require.NoError(t, err)
+
+	cw, ok := conn.(interface{ CloseWrite() error })
+	require.True(t, ok, "expected half close support")
+	require.NoError(t, cw.CloseWrite())
+
+	// The peer reads EOF while the read side stays open
+	assert.Equal(t, "hello", <-received)
+
+	resp, err := io.ReadAll(conn)
+	require.NoError(t, err)
+	assert.Equal(t, "bye", string(resp))
+}
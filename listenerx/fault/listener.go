@@ -0,0 +1,79 @@
+package fault
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/oligarch316/go-netx"
+)
+
+type dialer struct {
+	netx.Dialer
+	injector *Injector
+}
+
+// Dialer wraps d to inject dial failures and latency, and to inject faults
+// into every connection it dials.
+func (i *Injector) Dialer(d netx.Dialer) netx.Dialer { return &dialer{Dialer: d, injector: i} }
+
+func (d *dialer) Dial() (net.Conn, error) {
+	return d.DialContext(context.Background())
+}
+
+func (d *dialer) DialContext(ctx context.Context) (net.Conn, error) {
+	return d.injector.dialContext(ctx, d.Dialer)
+}
+
+func (i *Injector) dialContext(ctx context.Context, d netx.Dialer) (net.Conn, error) {
+	if err := i.dialError(); err != nil {
+		return nil, err
+	}
+
+	if err := i.delay(0, ctx.Done(), time.Time{}); err != nil {
+		return nil, ctx.Err()
+	}
+
+	res, err := d.DialContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return i.Conn(res), nil
+}
+
+type listener struct {
+	netx.Listener
+	injector *Injector
+}
+
+// Listener wraps l to inject temporary Accept errors, faults into every
+// connection it accepts, and dial faults as with Dialer.
+func (i *Injector) Listener(l netx.Listener) netx.Listener {
+	return &listener{Listener: l, injector: i}
+}
+
+func (l *listener) Accept() (net.Conn, error) {
+	if err := l.injector.acceptError(); err != nil {
+		return nil, err
+	}
+
+	res, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return l.injector.Conn(res), nil
+}
+
+func (l *listener) Dial() (net.Conn, error) {
+	return l.DialContext(context.Background())
+}
+
+func (l *listener) DialContext(ctx context.Context) (net.Conn, error) {
+	return l.injector.dialContext(ctx, l.Listener)
+}
+
+// NewListener TODO.
+func NewListener(l netx.Listener, opts ...Option) netx.Listener { return New(opts...).Listener(l) }
+
+// NewDialer TODO.
+func NewDialer(d netx.Dialer, opts ...Option) netx.Dialer { return New(opts...).Dialer(d) }
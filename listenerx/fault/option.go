@@ -0,0 +1,38 @@
+package fault
+
+import "time"
+
+// WithSeed TODO.
+func WithSeed(seed int64) Option {
+	return func(p *Params) { p.Seed = seed }
+}
+
+// WithAcceptErrorRate TODO.
+func WithAcceptErrorRate(rate float64) Option {
+	return func(p *Params) { p.AcceptErrorRate = rate }
+}
+
+// WithDialErrorRate TODO.
+func WithDialErrorRate(rate float64) Option {
+	return func(p *Params) { p.DialErrorRate = rate }
+}
+
+// WithLatency TODO.
+func WithLatency(latency time.Duration) Option {
+	return func(p *Params) { p.Latency = latency }
+}
+
+// WithBandwidth TODO.
+func WithBandwidth(bytesPerSecond int64) Option {
+	return func(p *Params) { p.Bandwidth = bytesPerSecond }
+}
+
+// WithResetRate TODO.
+func WithResetRate(rate float64) Option {
+	return func(p *Params) { p.ResetRate = rate }
+}
+
+// WithPartialWriteRate TODO.
+func WithPartialWriteRate(rate float64) Option {
+	return func(p *Params) { p.PartialWriteRate = rate }
+}
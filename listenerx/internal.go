@@ -1,37 +1,513 @@
 package listenerx
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
 	"net"
+	"os"
+	"runtime"
+	"sync"
+	"time"
 
 	"github.com/oligarch316/go-netx"
-	"google.golang.org/grpc/test/bufconn"
 )
 
 const (
 	// InternalNetwork TODO.
 	InternalNetwork = "internal"
 
-	internalDefaultSize = 256
+	internalDefaultSize = 64 * 1024
+	internalChunkSize   = 32 * 1024
 )
 
-type internalAddr struct{}
+var (
+	errInternalRefused = errors.New("listenerx: internal: connection refused")
+	errInternalInUse   = errors.New("listenerx: internal: address in use")
+)
+
+// InternalOption TODO.
+type InternalOption func(*InternalParams)
+
+// InternalParams TODO.
+type InternalParams struct {
+	// Latency is the simulated one-way delay applied to every write.
+	Latency time.Duration
+
+	// Bandwidth is the simulated link rate in bytes per second, zero for none.
+	Bandwidth int64
+}
+
+type internalAddr struct{ name string }
+
+func (internalAddr) Network() string   { return InternalNetwork }
+func (ia internalAddr) String() string { return ia.name }
+
+// ----- Registry
+
+// internalRegistry is the process-wide internal network, mapping names to
+// endpoints for the lifetime of each listener.
+type internalRegistry struct {
+	mu        sync.Mutex
+	count     uint64
+	endpoints map[string]*internalEndpoint
+}
+
+var internalNetwork = &internalRegistry{endpoints: make(map[string]*internalEndpoint)}
+
+func (ir *internalRegistry) register(name string, ie *internalEndpoint) error {
+	ir.mu.Lock()
+	defer ir.mu.Unlock()
+
+	if name == "" {
+		for name == "" || ir.endpoints[name] != nil {
+			ir.count++
+			name = fmt.Sprintf("%s-%d", InternalNetwork, ir.count)
+		}
+	} else if ir.endpoints[name] != nil {
+		return fmt.Errorf("%w: %s", errInternalInUse, name)
+	}
+
+	ie.addr = internalAddr{name: name}
+	ir.endpoints[name] = ie
+	return nil
+}
+
+func (ir *internalRegistry) unregister(ie *internalEndpoint) {
+	ir.mu.Lock()
+	defer ir.mu.Unlock()
+
+	if ir.endpoints[ie.addr.name] == ie {
+		delete(ir.endpoints, ie.addr.name)
+	}
+}
+
+func (ir *internalRegistry) lookup(name string) (*internalEndpoint, error) {
+	ir.mu.Lock()
+	defer ir.mu.Unlock()
+
+	if ie, ok := ir.endpoints[name]; ok {
+		return ie, nil
+	}
+	return nil, fmt.Errorf("%w: %s", errInternalRefused, name)
+}
+
+// ----- Deadline
+
+// internalDeadline mirrors the deadline handling of net.Pipe: the cancel
+// channel is closed once the deadline elapses, and is only replaced if a new
+// deadline is set after that point, so that pending calls observe updates.
+type internalDeadline struct {
+	mu     sync.Mutex
+	timer  *time.Timer
+	cancel chan struct{}
+}
+
+func newInternalDeadline() *internalDeadline {
+	return &internalDeadline{cancel: make(chan struct{})}
+}
+
+func (id *internalDeadline) set(t time.Time) {
+	id.mu.Lock()
+	defer id.mu.Unlock()
+
+	if id.timer != nil && !id.timer.Stop() {
+		// Wait for the timer callback to finish closing cancel
+		<-id.cancel
+	}
+
+	id.timer = nil
+	closed := internalIsClosed(id.cancel)
+
+	if t.IsZero() {
+		if closed {
+			id.cancel = make(chan struct{})
+		}
+		return
+	}
+
+	if dur := time.Until(t); dur > 0 {
+		if closed {
+			id.cancel = make(chan struct{})
+		}
+		cancel := id.cancel
+		id.timer = time.AfterFunc(dur, func() { close(cancel) })
+		return
+	}
+
+	if !closed {
+		close(id.cancel)
+	}
+}
+
+func (id *internalDeadline) wait() <-chan struct{} {
+	id.mu.Lock()
+	defer id.mu.Unlock()
+	return id.cancel
+}
+
+func internalIsClosed(c <-chan struct{}) bool {
+	select {
+	case <-c:
+		return true
+	default:
+		return false
+	}
+}
+
+// ----- Buffer
+
+type internalChunk struct {
+	data  []byte
+	ready time.Time
+}
+
+// internalBuffer is a single direction of an internal connection. Writes are
+// split into chunks stamped with the time at which they become readable,
+// accounting for simulated latency and bandwidth.
+type internalBuffer struct {
+	params InternalParams
+	size   int
+
+	mu          sync.Mutex
+	chunks      []internalChunk
+	queued      int
+	linkFree    time.Time
+	signal      chan struct{}
+	readClosed  bool
+	writeClosed bool
+}
+
+func newInternalBuffer(size int, params InternalParams) *internalBuffer {
+	return &internalBuffer{params: params, size: size, signal: make(chan struct{})}
+}
+
+// broadcast must be called with ib.mu held.
+func (ib *internalBuffer) broadcast() {
+	close(ib.signal)
+	ib.signal = make(chan struct{})
+}
+
+// push must be called with ib.mu held.
+func (ib *internalBuffer) push(data []byte) {
+	ready := time.Now()
+
+	if ib.params.Bandwidth > 0 {
+		if ib.linkFree.After(ready) {
+			ready = ib.linkFree
+		}
+		ready = ready.Add(time.Duration(int64(len(data)) * int64(time.Second) / ib.params.Bandwidth))
+		ib.linkFree = ready
+	}
+
+	ib.chunks = append(ib.chunks, internalChunk{
+		data:  append([]byte(nil), data...),
+		ready: ready.Add(ib.params.Latency),
+	})
+	ib.queued += len(data)
+}
+
+func (ib *internalBuffer) write(p []byte, deadline <-chan struct{}) (int, error) {
+	var n int
+
+	for {
+		ib.mu.Lock()
+
+		switch {
+		case ib.writeClosed:
+			ib.mu.Unlock()
+			return n, net.ErrClosed
+		case ib.readClosed:
+			ib.mu.Unlock()
+			return n, io.ErrClosedPipe
+		case internalIsClosed(deadline):
+			ib.mu.Unlock()
+			return n, os.ErrDeadlineExceeded
+		}
+
+		start := n
+		for n < len(p) && (ib.size <= 0 || ib.queued < ib.size) {
+			count := len(p) - n
+			if count > internalChunkSize {
+				count = internalChunkSize
+			}
+			if ib.size > 0 && count > ib.size-ib.queued {
+				count = ib.size - ib.queued
+			}
+			ib.push(p[n : n+count])
+			n += count
+		}
+
+		if n > start {
+			ib.broadcast()
+		}
+
+		if n == len(p) {
+			ib.mu.Unlock()
+			return n, nil
+		}
+
+		signal := ib.signal
+		ib.mu.Unlock()
+
+		select {
+		case <-signal:
+		case <-deadline:
+		}
+	}
+}
 
-func (internalAddr) Network() string { return InternalNetwork }
-func (internalAddr) String() string  { return InternalNetwork }
+func (ib *internalBuffer) read(p []byte, deadline <-chan struct{}) (int, error) {
+	for {
+		ib.mu.Lock()
 
-type internalListener struct{ *bufconn.Listener }
+		switch {
+		case ib.readClosed:
+			ib.mu.Unlock()
+			return 0, net.ErrClosed
+		case internalIsClosed(deadline):
+			ib.mu.Unlock()
+			return 0, os.ErrDeadlineExceeded
+		case len(ib.chunks) < 1 && ib.writeClosed:
+			ib.mu.Unlock()
+			return 0, io.EOF
+		}
+
+		var wait time.Duration
+
+		if len(ib.chunks) > 0 {
+			head := &ib.chunks[0]
+
+			if wait = time.Until(head.ready); wait <= 0 {
+				n := copy(p, head.data)
+				if head.data = head.data[n:]; len(head.data) < 1 {
+					ib.chunks[0] = internalChunk{}
+					ib.chunks = ib.chunks[1:]
+				}
+
+				ib.queued -= n
+				ib.broadcast()
+				ib.mu.Unlock()
+				return n, nil
+			}
+		}
+
+		signal := ib.signal
+		ib.mu.Unlock()
+
+		var (
+			timer *time.Timer
+			ready <-chan time.Time
+		)
+
+		if wait > 0 {
+			timer = time.NewTimer(wait)
+			ready = timer.C
+		}
+
+		select {
+		case <-signal:
+		case <-deadline:
+		case <-ready:
+		}
+
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+}
+
+func (ib *internalBuffer) closeRead() {
+	ib.mu.Lock()
+	defer ib.mu.Unlock()
+
+	ib.readClosed, ib.chunks, ib.queued = true, nil, 0
+	ib.broadcast()
+}
+
+func (ib *internalBuffer) closeWrite() {
+	ib.mu.Lock()
+	defer ib.mu.Unlock()
+
+	ib.writeClosed = true
+	ib.broadcast()
+}
+
+// ----- Conn
+
+type internalConn struct {
+	addr   internalAddr
+	rx, tx *internalBuffer
+
+	readDeadline, writeDeadline *internalDeadline
+	closeOnce                   sync.Once
+}
+
+func newInternalConnPair(addr internalAddr, size int, params InternalParams) (*internalConn, *internalConn) {
+	var (
+		a = newInternalBuffer(size, params)
+		b = newInternalBuffer(size, params)
+	)
+
+	newConn := func(rx, tx *internalBuffer) *internalConn {
+		return &internalConn{
+			addr:          addr,
+			rx:            rx,
+			tx:            tx,
+			readDeadline:  newInternalDeadline(),
+			writeDeadline: newInternalDeadline(),
+		}
+	}
+
+	return newConn(a, b), newConn(b, a)
+}
+
+func (ic *internalConn) Read(p []byte) (int, error) {
+	return ic.rx.read(p, ic.readDeadline.wait())
+}
+
+func (ic *internalConn) Write(p []byte) (int, error) {
+	return ic.tx.write(p, ic.writeDeadline.wait())
+}
+
+func (ic *internalConn) Close() error {
+	ic.closeOnce.Do(func() {
+		ic.rx.closeRead()
+		ic.tx.closeWrite()
+	})
+	return nil
+}
+
+// CloseWrite shuts down the writing side only, the peer reads EOF once any
+// buffered data is consumed. Mirrors (*net.TCPConn).CloseWrite.
+func (ic *internalConn) CloseWrite() error {
+	ic.tx.closeWrite()
+	return nil
+}
+
+func (ic *internalConn) LocalAddr() net.Addr  { return ic.addr }
+func (ic *internalConn) RemoteAddr() net.Addr { return ic.addr }
+
+func (ic *internalConn) SetDeadline(t time.Time) error {
+	ic.readDeadline.set(t)
+	ic.writeDeadline.set(t)
+	return nil
+}
+
+func (ic *internalConn) SetReadDeadline(t time.Time) error {
+	ic.readDeadline.set(t)
+	return nil
+}
+
+func (ic *internalConn) SetWriteDeadline(t time.Time) error {
+	ic.writeDeadline.set(t)
+	return nil
+}
+
+// ----- Listener
+
+// internalEndpoint holds the state of an internal listener. The registry
+// references endpoints rather than listeners, so that a listener dropped
+// without Close can still be collected and closed by its finalizer.
+type internalEndpoint struct {
+	params InternalParams
+	size   int
+	addr   internalAddr
+
+	conns     chan net.Conn
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func (ie *internalEndpoint) accept() (net.Conn, error) {
+	select {
+	case conn := <-ie.conns:
+		return conn, nil
+	case <-ie.done:
+		return nil, net.ErrClosed
+	}
+}
+
+func (ie *internalEndpoint) close() {
+	ie.closeOnce.Do(func() {
+		internalNetwork.unregister(ie)
+		close(ie.done)
+	})
+}
+
+func (ie *internalEndpoint) dialContext(ctx context.Context) (net.Conn, error) {
+	if internalIsClosed(ie.done) {
+		return nil, fmt.Errorf("%w: %s", errInternalRefused, ie.addr)
+	}
+
+	client, server := newInternalConnPair(ie.addr, ie.size, ie.params)
+
+	select {
+	case ie.conns <- server:
+		return client, nil
+	case <-ie.done:
+		return nil, fmt.Errorf("%w: %s", errInternalRefused, ie.addr)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+type internalListener struct{ endpoint *internalEndpoint }
 
 // NewInternal TODO.
-func NewInternal(size int) netx.Listener {
-	return &internalListener{Listener: bufconn.Listen(size)}
+func NewInternal(size int, opts ...InternalOption) netx.Listener {
+	// Anonymous registration cannot collide
+	res, _ := ListenInternal("", size, opts...)
+	return res
 }
 
-func (internalListener) Addr() net.Addr { return internalAddr{} }
+// ListenInternal TODO.
+//
+// The listener should be closed once no longer needed, to release its name.
+// A listener that becomes unreachable without being closed is closed by the
+// garbage collector.
+func ListenInternal(name string, size int, opts ...InternalOption) (netx.Listener, error) {
+	var params InternalParams
+	for _, opt := range opts {
+		opt(&params)
+	}
 
-func (ia *internalListener) Accept() (net.Conn, error) {
-	conn, err := ia.Listener.Accept()
-	if err != nil && err.Error() == "closed" {
-		err = net.ErrClosed
+	endpoint := &internalEndpoint{
+		params: params,
+		size:   size,
+		conns:  make(chan net.Conn),
+		done:   make(chan struct{}),
 	}
-	return conn, err
+
+	if err := internalNetwork.register(name, endpoint); err != nil {
+		return nil, err
+	}
+
+	res := &internalListener{endpoint: endpoint}
+	runtime.SetFinalizer(res, (*internalListener).Close)
+	return res, nil
+}
+
+func (il *internalListener) Addr() net.Addr { return il.endpoint.addr }
+
+func (il *internalListener) Accept() (net.Conn, error) {
+	res, err := il.endpoint.accept()
+	runtime.KeepAlive(il)
+	return res, err
+}
+
+func (il *internalListener) Close() error {
+	il.endpoint.close()
+	return nil
+}
+
+func (il *internalListener) Dial() (net.Conn, error) {
+	return il.DialContext(context.Background())
+}
+
+func (il *internalListener) DialContext(ctx context.Context) (net.Conn, error) {
+	res, err := il.endpoint.dialContext(ctx)
+	runtime.KeepAlive(il)
+	return res, err
 }
@@ -0,0 +1,237 @@
+package listenerx_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/oligarch316/go-netx/listenerx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func internalPair(t *testing.T, size int, opts ...listenerx.InternalOption) (client, server net.Conn) {
+	t.Helper()
+
+	l := listenerx.NewInternal(size, opts...)
+	t.Cleanup(func() { l.Close() })
+
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		conn, _ := l.Accept()
+		accepted <- conn
+	}()
+
+	client, err := l.Dial()
+	require.NoError(t, err)
+
+	server = <-accepted
+	require.NotNil(t, server)
+
+	t.Cleanup(func() {
+		client.Close()
+		server.Close()
+	})
+
+	return client, server
+}
+
+func TestInternalTransfer(t *testing.T) {
+	subtests := []struct {
+		name string
+		size int
+	}{
+		{name: "bounded", size: 1024},
+		{name: "unbounded", size: 0},
+	}
+
+	for _, item := range subtests {
+		subtest := item
+
+		t.Run(subtest.name, func(t *testing.T) {
+			t.Parallel()
+
+			client, server := internalPair(t, subtest.size)
+			payload := bytes.Repeat([]byte("netx"), 256*1024)
+
+			go func() {
+				client.Write(payload)
+				client.Close()
+			}()
+
+			received, err := io.ReadAll(server)
+			require.NoError(t, err)
+			assert.Equal(t, payload, received)
+
+			_, err = server.Write([]byte("late"))
+			assert.ErrorIs(t, err, io.ErrClosedPipe)
+		})
+	}
+}
+
+func TestInternalDeadline(t *testing.T) {
+	t.Parallel()
+
+	client, server := internalPair(t, 4)
+
+	require.NoError(t, server.SetReadDeadline(time.Now().Add(10*time.Millisecond)))
+	_, err := server.Read(make([]byte, 1))
+	assert.ErrorIs(t, err, os.ErrDeadlineExceeded)
+
+	var netErr net.Error
+	require.True(t, errors.As(err, &netErr))
+	assert.True(t, netErr.Timeout())
+
+	// A full buffer blocks writes until the deadline
+	require.NoError(t, client.SetWriteDeadline(time.Now().Add(10*time.Millisecond)))
+	n, err := client.Write([]byte("overflow"))
+	assert.Equal(t, 4, n)
+	assert.ErrorIs(t, err, os.ErrDeadlineExceeded)
+
+	// Clearing the deadline restores normal operation
+	require.NoError(t, server.SetReadDeadline(time.Time{}))
+	buf := make([]byte, 4)
+	_, err = io.ReadFull(server, buf)
+	require.NoError(t, err)
+	assert.Equal(t, "over", string(buf))
+}
+
+func TestInternalLatency(t *testing.T) {
+	t.Parallel()
+
+	const latency = 50 * time.Millisecond
+
+	client, server := internalPair(t, 0, listenerx.WithInternalLatency(latency))
+
+	start := time.Now()
+	_, err := client.Write([]byte("ping"))
+	require.NoError(t, err)
+
+	_, err = io.ReadFull(server, make([]byte, 4))
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), latency)
+}
+
+func TestInternalBandwidth(t *testing.T) {
+	t.Parallel()
+
+	client, server := internalPair(t, 0, listenerx.WithInternalBandwidth(64*1024))
+
+	start := time.Now()
+	_, err := client.Write(make([]byte, 8*1024))
+	require.NoError(t, err)
+
+	_, err = io.ReadFull(server, make([]byte, 8*1024))
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 125*time.Millisecond)
+}
+
+func TestInternalAddr(t *testing.T) {
+	t.Parallel()
+
+	a, b := listenerx.NewInternal(0), listenerx.NewInternal(0)
+	defer a.Close()
+	defer b.Close()
+
+	assert.Equal(t, listenerx.InternalNetwork, a.Addr().Network())
+	assert.NotEqual(t, a.Addr().String(), b.Addr().String())
+
+	require.NoError(t, a.Close())
+
+	_, err := a.Accept()
+	assert.ErrorIs(t, err, net.ErrClosed)
+
+	_, err = a.Dial()
+	assert.Error(t, err)
+}
+
+func TestInternalRegistry(t *testing.T) {
+	t.Parallel()
+
+	const name = "test-internal-registry"
+
+	l, err := listenerx.ListenInternal(name, 0)
+	require.NoError(t, err)
+	assert.Equal(t, name, l.Addr().String())
+
+	_, err = listenerx.ListenInternal(name, 0)
+	assert.Error(t, err, "expected name collision")
+
+	go func() {
+		conn, err := l.Accept()
+		if err == nil {
+			conn.Write([]byte("hello"))
+			conn.Close()
+		}
+	}()
+
+	conn, err := listenerx.Dial(listenerx.InternalNetwork, name)
+	require.NoError(t, err)
+
+	received, err := io.ReadAll(conn)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(received))
+
+	require.NoError(t, l.Close())
+
+	_, err = listenerx.Dial(listenerx.InternalNetwork, name)
+	assert.Error(t, err, "expected dial after close to fail")
+
+	// The name is free for reuse once closed
+	l, err = listenerx.ListenInternal(name, 0)
+	require.NoError(t, err)
+	l.Close()
+}
+
+func TestInternalUnreachable(t *testing.T) {
+	t.Parallel()
+
+	// Scoped so that the listener is unreachable once the name is taken
+	name := func() string { return listenerx.NewInternal(0).Addr().String() }()
+
+	deadline := time.Now().Add(5 * time.Second)
+
+	for {
+		runtime.GC()
+
+		_, err := listenerx.Dial(listenerx.InternalNetwork, name)
+		if err != nil {
+			break
+		}
+
+		require.True(t, time.Now().Before(deadline), "expected unreachable listener to be closed")
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestInternalCloseWrite(t *testing.T) {
+	t.Parallel()
+
+	client, server := internalPair(t, 0)
+
+	cw, ok := client.(interface{ CloseWrite() error })
+	require.True(t, ok, "expected half close support")
+
+	_, err := client.Write([]byte("ping"))
+	require.NoError(t, err)
+	require.NoError(t, cw.CloseWrite())
+
+	// Buffered data is still delivered ahead of EOF
+	received, err := io.ReadAll(server)
+	require.NoError(t, err)
+	assert.Equal(t, "ping", string(received))
+
+	// The other direction remains open
+	_, err = server.Write([]byte("pong"))
+	require.NoError(t, err)
+
+	buf := make([]byte, 4)
+	_, err = io.ReadFull(client, buf)
+	require.NoError(t, err)
+	assert.Equal(t, "pong", string(buf))
+}
@@ -1,6 +1,7 @@
 package listenerx
 
 import (
+	"context"
 	"net"
 
 	"github.com/oligarch316/go-netx"
@@ -9,9 +10,28 @@ import (
 // New TODO.
 func New(network, address string) (netx.Listener, error) {
 	if network == InternalNetwork {
-		return NewInternal(internalDefaultSize), nil
+		return ListenInternal(address, internalDefaultSize)
 	}
 
 	l, err := net.Listen(network, address)
 	return NewBasic(l), err
 }
+
+// Dial TODO.
+func Dial(network, address string) (net.Conn, error) {
+	return DialContext(context.Background(), network, address)
+}
+
+// DialContext TODO.
+func DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	if network == InternalNetwork {
+		ie, err := internalNetwork.lookup(address)
+		if err != nil {
+			return nil, err
+		}
+		return ie.dialContext(ctx)
+	}
+
+	var dialer net.Dialer
+	return dialer.DialContext(ctx, network, address)
+}
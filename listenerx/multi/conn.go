@@ -0,0 +1,228 @@
+package multi
+
+import (
+	"errors"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ConnStats reports connection counts for a listener. Accepted is always
+// maintained, the remaining fields only when connections are wrapped, i.e.
+// when tracking or connection timeouts are enabled. Bytes of TLS listeners are
+// counted as sent on the wire, encrypted.
+type ConnStats struct {
+	Accepted, Active        int64
+	BytesRead, BytesWritten int64
+}
+
+type connStats struct {
+	accepted, active        int64
+	bytesRead, bytesWritten int64
+
+	set connSet
+}
+
+func (cs *connStats) snapshot() ConnStats {
+	return ConnStats{
+		Accepted:     atomic.LoadInt64(&cs.accepted),
+		Active:       atomic.LoadInt64(&cs.active),
+		BytesRead:    atomic.LoadInt64(&cs.bytesRead),
+		BytesWritten: atomic.LoadInt64(&cs.bytesWritten),
+	}
+}
+
+// ConnTimeoutParams TODO.
+type ConnTimeoutParams struct {
+	// ReadIdle and WriteIdle close connections that have not completed a read
+	// or write respectively for the given duration, zero for none.
+	ReadIdle, WriteIdle time.Duration
+
+	// MaxLifetime closes connections this long after they are accepted, zero
+	// for none.
+	MaxLifetime time.Duration
+}
+
+func (ctp ConnTimeoutParams) enabled() bool {
+	return ctp.ReadIdle > 0 || ctp.WriteIdle > 0 || ctp.MaxLifetime > 0
+}
+
+// connTimer enforces ConnTimeoutParams with a single timer per connection,
+// re-armed lazily for the earliest deadline so that reads and writes only
+// record a timestamp.
+type connTimer struct {
+	params    ConnTimeoutParams
+	onExpire  func(ConnTimeoutReason)
+	start     time.Time
+	lastRead  int64
+	lastWrite int64
+
+	mu    sync.Mutex
+	timer *time.Timer
+	done  bool
+}
+
+func newConnTimer(params ConnTimeoutParams, onExpire func(ConnTimeoutReason)) *connTimer {
+	now := time.Now()
+
+	return &connTimer{
+		params:    params,
+		onExpire:  onExpire,
+		start:     now,
+		lastRead:  now.UnixNano(),
+		lastWrite: now.UnixNano(),
+	}
+}
+
+func (ct *connTimer) arm() {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+	ct.timer = time.AfterFunc(ct.next(time.Now()), ct.check)
+}
+
+func (ct *connTimer) touch(last *int64) { atomic.StoreInt64(last, time.Now().UnixNano()) }
+
+// deadlines returns the time at which each enabled timeout expires.
+func (ct *connTimer) deadlines() map[ConnTimeoutReason]time.Time {
+	res := make(map[ConnTimeoutReason]time.Time, 3)
+
+	if ct.params.ReadIdle > 0 {
+		res[ConnTimeoutReadIdle] = time.Unix(0, atomic.LoadInt64(&ct.lastRead)).Add(ct.params.ReadIdle)
+	}
+	if ct.params.WriteIdle > 0 {
+		res[ConnTimeoutWriteIdle] = time.Unix(0, atomic.LoadInt64(&ct.lastWrite)).Add(ct.params.WriteIdle)
+	}
+	if ct.params.MaxLifetime > 0 {
+		res[ConnTimeoutMaxLifetime] = ct.start.Add(ct.params.MaxLifetime)
+	}
+
+	return res
+}
+
+func (ct *connTimer) next(now time.Time) time.Duration {
+	var res time.Duration = -1
+
+	for _, deadline := range ct.deadlines() {
+		if wait := deadline.Sub(now); res < 0 || wait < res {
+			res = wait
+		}
+	}
+
+	return res
+}
+
+func (ct *connTimer) check() {
+	now := time.Now()
+
+	for _, reason := range []ConnTimeoutReason{ConnTimeoutMaxLifetime, ConnTimeoutReadIdle, ConnTimeoutWriteIdle} {
+		if deadline, ok := ct.deadlines()[reason]; ok && !now.Before(deadline) {
+			if ct.stop() {
+				ct.onExpire(reason)
+			}
+			return
+		}
+	}
+
+	// Activity since the timer was armed pushed every deadline back
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	if !ct.done {
+		ct.timer.Reset(ct.next(now))
+	}
+}
+
+// stop reports whether this call stopped the timer.
+func (ct *connTimer) stop() bool {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	if ct.done {
+		return false
+	}
+
+	ct.done = true
+	if ct.timer != nil {
+		ct.timer.Stop()
+	}
+	return true
+}
+
+var errCloseWriteUnsupported = errors.New("close write not supported")
+
+// trackedConn wraps accepted connections when tracking or timeouts are
+// enabled. Connections of TLS listeners are wrapped beneath TLS, so that
+// services still see a *tls.Conn.
+type trackedConn struct {
+	net.Conn
+	stats     *connStats
+	timer     *connTimer
+	closeOnce sync.Once
+}
+
+func newTrackedConn(conn net.Conn, stats *connStats, timeouts ConnTimeoutParams, onTimeout func(ConnTimeoutReason)) *trackedConn {
+	atomic.AddInt64(&stats.active, 1)
+
+	res := &trackedConn{Conn: conn, stats: stats}
+	stats.set.add(res)
+
+	if timeouts.enabled() {
+		res.timer = newConnTimer(timeouts, func(reason ConnTimeoutReason) {
+			res.Close()
+			onTimeout(reason)
+		})
+		res.timer.arm()
+	}
+
+	return res
+}
+
+func (tc *trackedConn) Read(p []byte) (int, error) {
+	n, err := tc.Conn.Read(p)
+	atomic.AddInt64(&tc.stats.bytesRead, int64(n))
+
+	if n > 0 && tc.timer != nil {
+		tc.timer.touch(&tc.timer.lastRead)
+	}
+	return n, err
+}
+
+func (tc *trackedConn) Write(p []byte) (int, error) {
+	n, err := tc.Conn.Write(p)
+	atomic.AddInt64(&tc.stats.bytesWritten, int64(n))
+
+	if n > 0 && tc.timer != nil {
+		tc.timer.touch(&tc.timer.lastWrite)
+	}
+	return n, err
+}
+
+func (tc *trackedConn) Close() error {
+	// Close the underlying connection first, so that it is closed by the time
+	// a drain observes its removal
+	err := tc.Conn.Close()
+
+	tc.closeOnce.Do(func() {
+		atomic.AddInt64(&tc.stats.active, -1)
+		tc.stats.set.remove(tc)
+
+		if tc.timer != nil {
+			tc.timer.stop()
+		}
+	})
+
+	return err
+}
+
+// CloseWrite shuts down the writing side of the underlying connection, where
+// supported, so that wrapping does not hide half close from services.
+func (tc *trackedConn) CloseWrite() error {
+	if cw, ok := tc.Conn.(interface{ CloseWrite() error }); ok {
+		return cw.CloseWrite()
+	}
+	return errCloseWriteUnsupported
+}
+
+// NetConn TODO.
+func (tc *trackedConn) NetConn() net.Conn { return tc.Conn }
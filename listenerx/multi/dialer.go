@@ -2,17 +2,52 @@ package multi
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net"
 	"sort"
+	"syscall"
+	"time"
 
 	"github.com/oligarch316/go-netx"
 	"github.com/oligarch316/go-netx/addressx"
+	"github.com/oligarch316/go-netx/listenerx/retry"
+	"github.com/oligarch316/go-netx/tracex"
 )
 
+// DialRetryError TODO.
+type DialRetryError struct {
+	error
+	Attempts int
+}
+
+func (dre DialRetryError) Unwrap() error { return dre.error }
+
+func (dre DialRetryError) Error() string {
+	return fmt.Sprintf("dial failed after %d attempt(s): %s", dre.Attempts, dre.error)
+}
+
+func dialRetriable(err error) bool {
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+
+	var ne net.Error
+	return errors.As(err, &ne) && ne.Temporary()
+}
+
 // DialerParams TODO.
 type DialerParams struct {
 	AddressOrdering addressx.Ordering
 	Strategy        DialStrategy
+	RetryDelay      retry.DelayFunc
+
+	// RetryMaxAttempts caps the number of dial attempts made when RetryDelay
+	// is set. A value <= 0 removes the cap, in which case retries are only
+	// attempted for contexts that carry a deadline.
+	RetryMaxAttempts int
+
+	Tracer tracex.Tracer
 }
 
 // Dialer TODO.
@@ -22,12 +57,18 @@ type Dialer struct {
 }
 
 func newDialer(params DialerParams, ls []netx.Listener) *Dialer {
+	if params.Tracer == nil {
+		params.Tracer = tracex.Noop
+	}
 	return &Dialer{params: params, set: newDialSet(ls)}
 }
 
 // Len TODO.
 func (d *Dialer) Len() int { return len(d.set.listeners) }
 
+// Params TODO.
+func (d *Dialer) Params() DialerParams { return d.params }
+
 // Resolve TODO.
 func (d *Dialer) Resolve() []SetAddr {
 	res := d.set.Addrs()
@@ -56,5 +97,66 @@ func (d *Dialer) Dial() (net.Conn, error) {
 
 // DialContext TODO.
 func (d *Dialer) DialContext(ctx context.Context) (net.Conn, error) {
-	return d.params.Strategy(ctx, d.Resolve(), d.DialContextHash)
+	ctx, span := d.params.Tracer.Start(ctx, "multi.dial", tracex.Attr("netx.dialer.set", d.set.id))
+	defer span.End()
+
+	res, err := d.dialContext(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	span.SetAttributes(tracex.Attr("net.peer.addr", res.RemoteAddr().String()))
+	return res, nil
+}
+
+func (d *Dialer) dialContext(ctx context.Context) (net.Conn, error) {
+	if d.params.RetryDelay == nil {
+		return d.params.Strategy(ctx, d.Resolve(), d.DialContextHash)
+	}
+
+	delay := retry.NewDelay(d.params.RetryDelay)
+
+	for {
+		res, err := d.params.Strategy(ctx, d.Resolve(), d.DialContextHash)
+		if err == nil {
+			return res, nil
+		}
+
+		attempt, delayDuration := delay.Next()
+		retryErr := DialRetryError{error: err, Attempts: attempt}
+
+		if !dialRetriable(err) {
+			// Permanent error => give up immediately
+			return nil, retryErr
+		}
+
+		if max := d.params.RetryMaxAttempts; max > 0 && attempt >= max {
+			// Attempts exhausted => give up
+			return nil, retryErr
+		}
+
+		deadline, ok := ctx.Deadline()
+
+		if !ok && d.params.RetryMaxAttempts <= 0 {
+			// Neither a cap nor a deadline => never retry rather than forever
+			return nil, retryErr
+		}
+
+		if ok && time.Until(deadline) < delayDuration {
+			// Retry delay would outlast the context => give up early
+			return nil, retryErr
+		}
+
+		timer := time.NewTimer(delayDuration)
+
+		select {
+		case <-timer.C:
+			// Retry delay has elapsed, continue
+		case <-ctx.Done():
+			// Context expired during retry delay
+			timer.Stop()
+			return nil, retryErr
+		}
+	}
 }
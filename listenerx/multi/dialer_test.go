@@ -0,0 +1,148 @@
+package multi_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/oligarch316/go-netx"
+	"github.com/oligarch316/go-netx/listenerx/multi"
+	"github.com/oligarch316/go-netx/listenerx/retry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockAddr string
+
+func (ma mockAddr) Network() string { return "mock" }
+func (ma mockAddr) String() string  { return string(ma) }
+
+type mockListener struct {
+	net.Listener
+
+	name     string
+	dialErrs []error
+	dials    int
+}
+
+func (ml *mockListener) Addr() net.Addr { return mockAddr(ml.name) }
+
+func (ml *mockListener) Dial() (net.Conn, error) {
+	return ml.DialContext(context.Background())
+}
+
+func (ml *mockListener) DialContext(context.Context) (net.Conn, error) {
+	defer func() { ml.dials++ }()
+
+	if ml.dials < len(ml.dialErrs) {
+		return nil, ml.dialErrs[ml.dials]
+	}
+
+	client, server := net.Pipe()
+	server.Close()
+	return client, nil
+}
+
+func TestDialerRetry(t *testing.T) {
+	var (
+		errRefused   = fmt.Errorf("mock dial: %w", syscall.ECONNREFUSED)
+		errPermanent = errors.New("mock permanent error")
+		retryDelay   = retry.DelayFuncConstant(time.Millisecond)
+	)
+
+	subtests := []struct {
+		name             string
+		dialErrs         []error
+		timeout          time.Duration
+		noDeadline       bool
+		maxAttempts      int
+		expectedAttempts int
+		expectedErr      error
+	}{
+		{
+			name:             "success after retriable errors",
+			dialErrs:         []error{errRefused, errRefused},
+			timeout:          time.Second,
+			expectedAttempts: 3,
+			expectedErr:      nil,
+		},
+		{
+			name:             "permanent error",
+			dialErrs:         []error{errRefused, errPermanent},
+			timeout:          time.Second,
+			expectedAttempts: 2,
+			expectedErr:      errPermanent,
+		},
+		{
+			name:             "context deadline",
+			dialErrs:         []error{errRefused, errRefused, errRefused, errRefused},
+			timeout:          0,
+			expectedAttempts: 1,
+			expectedErr:      syscall.ECONNREFUSED,
+		},
+		{
+			name:             "max attempts",
+			dialErrs:         []error{errRefused, errRefused, errRefused, errRefused},
+			timeout:          time.Second,
+			maxAttempts:      2,
+			expectedAttempts: 2,
+			expectedErr:      syscall.ECONNREFUSED,
+		},
+		{
+			name:             "max attempts without deadline",
+			dialErrs:         []error{errRefused, errRefused},
+			noDeadline:       true,
+			maxAttempts:      5,
+			expectedAttempts: 3,
+			expectedErr:      nil,
+		},
+		{
+			name:             "uncapped without deadline",
+			dialErrs:         []error{errRefused, errRefused},
+			noDeadline:       true,
+			expectedAttempts: 1,
+			expectedErr:      syscall.ECONNREFUSED,
+		},
+	}
+
+	for _, item := range subtests {
+		subtest := item
+
+		t.Run(subtest.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				l = &mockListener{name: "mock", dialErrs: subtest.dialErrs}
+				d = multi.NewListener([]netx.Listener{l},
+					multi.WithDialerRetryDelay(retryDelay),
+					multi.WithDialerRetryMaxAttempts(subtest.maxAttempts),
+				)
+			)
+
+			ctx, cancel := context.WithTimeout(context.Background(), subtest.timeout)
+			defer cancel()
+
+			if subtest.noDeadline {
+				ctx = context.Background()
+			}
+
+			conn, err := d.DialContext(ctx)
+
+			if subtest.expectedErr == nil {
+				require.NoError(t, err)
+				conn.Close()
+				assert.Equal(t, subtest.expectedAttempts, l.dials)
+				return
+			}
+
+			var retryErr multi.DialRetryError
+			require.ErrorAs(t, err, &retryErr)
+			assert.ErrorIs(t, err, subtest.expectedErr)
+			assert.Equal(t, subtest.expectedAttempts, retryErr.Attempts)
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package multi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var errDrainUntracked = errors.New("drain requires connection tracking")
+
+// DrainResult TODO.
+type DrainResult struct {
+	// Drained connections were closed by their owner, Forced connections were
+	// closed once the drain context expired.
+	Drained, Forced int
+}
+
+// connSet holds every open connection wrapped by a listener's runners.
+type connSet struct {
+	mu      sync.Mutex
+	conns   map[*trackedConn]struct{}
+	removed int
+	signal  chan struct{}
+}
+
+func (cs *connSet) add(tc *trackedConn) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	if cs.conns == nil {
+		cs.conns = make(map[*trackedConn]struct{})
+	}
+	cs.conns[tc] = struct{}{}
+}
+
+func (cs *connSet) remove(tc *trackedConn) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	if _, ok := cs.conns[tc]; !ok {
+		return
+	}
+
+	delete(cs.conns, tc)
+	cs.removed++
+
+	if cs.signal != nil {
+		close(cs.signal)
+		cs.signal = nil
+	}
+}
+
+// state returns the number of open connections, the number removed so far,
+// and a channel closed upon the next removal.
+func (cs *connSet) state() (int, int, <-chan struct{}) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	if cs.signal == nil {
+		cs.signal = make(chan struct{})
+	}
+	return len(cs.conns), cs.removed, cs.signal
+}
+
+// closeAll closes every open connection, returning the number removed before
+// doing so and the number it closed. Both are counted under a single lock, so
+// that every connection is counted exactly once.
+func (cs *connSet) closeAll() (int, int) {
+	cs.mu.Lock()
+	removed := cs.removed
+	conns := make([]*trackedConn, 0, len(cs.conns))
+	for tc := range cs.conns {
+		conns = append(conns, tc)
+	}
+	cs.mu.Unlock()
+
+	for _, tc := range conns {
+		tc.Close()
+	}
+	return removed, len(conns)
+}
+
+// Drain waits for every connection handed out by the listener to be closed,
+// forcibly closing those remaining once ctx expires. Connections must be
+// tracked, so Drain fails unless tracking or connection timeouts are enabled.
+// Runners should be closed beforehand, as connections accepted during the
+// drain are waited for as well.
+func (l *Listener) Drain(ctx context.Context) (DrainResult, error) {
+	if !l.runnerParams.TrackConns && !l.runnerParams.ConnTimeouts.enabled() {
+		return DrainResult{}, errDrainUntracked
+	}
+
+	_, start, _ := l.stats.set.state()
+
+	for {
+		open, removed, signal := l.stats.set.state()
+		if open < 1 {
+			return DrainResult{Drained: removed - start}, nil
+		}
+
+		select {
+		case <-signal:
+		case <-ctx.Done():
+			removed, forced := l.stats.set.closeAll()
+
+			res := DrainResult{Drained: removed - start, Forced: forced}
+			return res, fmt.Errorf("forced close of %d connection(s): %w", forced, ctx.Err())
+		}
+	}
+}
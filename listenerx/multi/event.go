@@ -21,6 +21,20 @@ func (re runnerEvent) errString(message string) string {
 func (re runnerEvent) Addr() net.Addr { return re.addr }
 func (re runnerEvent) Unwrap() error  { return re.err }
 
+// ConnTimeoutReason TODO.
+type ConnTimeoutReason string
+
+const (
+	// ConnTimeoutReadIdle TODO.
+	ConnTimeoutReadIdle ConnTimeoutReason = "read idle"
+
+	// ConnTimeoutWriteIdle TODO.
+	ConnTimeoutWriteIdle ConnTimeoutReason = "write idle"
+
+	// ConnTimeoutMaxLifetime TODO.
+	ConnTimeoutMaxLifetime ConnTimeoutReason = "max lifetime"
+)
+
 // RunnerEvent TODO.
 type RunnerEvent interface {
 	Addr() net.Addr
@@ -44,6 +58,22 @@ type (
 		RetryDelayDuration time.Duration
 		runnerEvent
 	}
+
+	// RunnerEventConnTimeout reports an accepted connection closed for
+	// exceeding one of its timeouts.
+	RunnerEventConnTimeout struct {
+		Reason     ConnTimeoutReason
+		RemoteAddr net.Addr
+		runnerEvent
+	}
+
+	// RunnerEventAcceptRateLimited TODO.
+	RunnerEventAcceptRateLimited struct {
+		// Aggregate distinguishes the listener-wide limit from the per-source
+		// limit.
+		Aggregate bool
+		runnerEvent
+	}
 )
 
 func (e RunnerEventCloseContextExpiredError) Error() string {
@@ -61,3 +91,14 @@ func (e RunnerEventUnprocessedConnectionCloseError) Error() string {
 func (e RunnerEventTemporaryAcceptError) Error() string {
 	return e.errString("temporary accept error")
 }
+
+func (e RunnerEventAcceptRateLimited) Error() string {
+	if e.Aggregate {
+		return e.errString("aggregate connection shed")
+	}
+	return e.errString("connection shed")
+}
+
+func (e RunnerEventConnTimeout) Error() string {
+	return e.errString(fmt.Sprintf("connection %s timeout", e.Reason))
+}
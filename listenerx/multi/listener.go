@@ -1,13 +1,16 @@
 package multi
 
 import (
+	"crypto/tls"
 	"fmt"
+	"net"
 	"time"
 
 	"github.com/oligarch316/go-netx"
 	"github.com/oligarch316/go-netx/addressx"
 	"github.com/oligarch316/go-netx/listenerx"
 	"github.com/oligarch316/go-netx/listenerx/retry"
+	"github.com/oligarch316/go-netx/tracex"
 )
 
 // ListenerOption TODO.
@@ -25,11 +28,15 @@ func defaultListenerParams() ListenerParams {
 			AddressOrdering: addressx.Ordering{
 				addressx.ByPriorityNetwork(listenerx.InternalNetwork, "unix", "tcp"),
 			},
-			Strategy: DialStrategyFirstOnly,
+			Strategy:         DialStrategyFirstOnly,
+			RetryDelay:       nil,
+			RetryMaxAttempts: 10,
+			Tracer:           tracex.Noop,
 		},
 		Runner: RunnerParams{
 			AcceptRetryDelay: retry.DelayFuncExponential(5*time.Millisecond, 1*time.Second, 2),
 			EventHandler:     func(RunnerEvent) {},
+			Tracer:           tracex.Noop,
 		},
 	}
 }
@@ -40,6 +47,7 @@ type Listener struct {
 	*mergeListener
 
 	runnerParams RunnerParams
+	stats        *connStats
 }
 
 // NewListener TODO.
@@ -51,20 +59,44 @@ func NewListener(ls []netx.Listener, opts ...ListenerOption) *Listener {
 
 	return &Listener{
 		Dialer:        newDialer(params.Dialer, ls),
-		mergeListener: newMergeListener(),
+		mergeListener: newMergeListener(params.Runner.AggregateAcceptRate),
 		runnerParams:  params.Runner,
+		stats:         new(connStats),
 	}
 }
 
 // Runners TODO.
 func (l *Listener) Runners() []*MergeRunner {
-	res := make([]*MergeRunner, l.Len())
+	res := make([]*MergeRunner, 0, l.Len())
 
-	for i, item := range l.set.listeners {
-		res[i] = newMergeRunner(l.runnerParams, item, l.mergeListener)
+	for _, item := range l.set.listeners {
+		// Accept from beneath TLS, so that connections are tracked beneath it
+		// and services are still handed a *tls.Conn
+		var (
+			source    net.Listener = item
+			tlsConfig *tls.Config
+		)
+
+		if tl, ok := item.(listenerx.TLS); ok {
+			source, tlsConfig = tl.Inner(), tl.TLSConfig()
+		}
+
+		// Sharded listeners present a single address to the dialer but accept
+		// on each of their shards independently
+		if sharded, ok := source.(listenerx.Sharded); ok {
+			for _, shard := range sharded.Shards() {
+				res = append(res, newMergeRunner(l.runnerParams, shard, tlsConfig, l.mergeListener, l.stats))
+			}
+			continue
+		}
+
+		res = append(res, newMergeRunner(l.runnerParams, source, tlsConfig, l.mergeListener, l.stats))
 	}
 
 	return res
 }
 
+// Stats TODO.
+func (l *Listener) Stats() ConnStats { return l.stats.snapshot() }
+
 func (l *Listener) String() string { return fmt.Sprintf("multi listener %d", l.set.id) }
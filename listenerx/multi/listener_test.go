@@ -0,0 +1,423 @@
+package multi_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/oligarch316/go-netx"
+	"github.com/oligarch316/go-netx/limitx"
+	"github.com/oligarch316/go-netx/listenerx"
+	"github.com/oligarch316/go-netx/listenerx/multi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockShardedListener struct {
+	*mockListener
+	shards []net.Listener
+}
+
+func (msl *mockShardedListener) Shards() []net.Listener { return msl.shards }
+
+func TestListenerShardedRunners(t *testing.T) {
+	var (
+		plain   = &mockListener{name: "plain"}
+		sharded = &mockShardedListener{
+			mockListener: &mockListener{name: "sharded"},
+			shards: []net.Listener{
+				&mockListener{name: "sharded"},
+				&mockListener{name: "sharded"},
+				&mockListener{name: "sharded"},
+			},
+		}
+	)
+
+	l := multi.NewListener([]netx.Listener{plain, sharded})
+
+	assert.Equal(t, 2, l.Len())
+	assert.Len(t, l.Resolve(), 2)
+	assert.Len(t, l.Runners(), 4)
+}
+
+type mockEvent struct {
+	multi.RunnerEvent
+	n int
+}
+
+func TestEventRecorder(t *testing.T) {
+	recorder := multi.NewEventRecorder(3)
+	assert.Empty(t, recorder.Events())
+
+	for i := 0; i < 5; i++ {
+		recorder.Record(mockEvent{n: i})
+	}
+
+	var actual []int
+	for _, item := range recorder.Events() {
+		actual = append(actual, item.Event.(mockEvent).n)
+	}
+
+	assert.Equal(t, []int{2, 3, 4}, actual, "expected most recent events, oldest first")
+}
+
+func TestListenerAcceptRate(t *testing.T) {
+	// A negligible refill rate leaves exactly the burst available
+	rate := limitx.Rate{Limit: 1e-9, Burst: 2}
+
+	subtests := []struct {
+		name              string
+		opt               multi.ListenerOption
+		allowed           []int
+		shed              int
+		expectedAggregate bool
+	}{
+		{
+			name:              "source",
+			opt:               multi.WithRunnerAcceptRate(rate),
+			allowed:           []int{0, 1, 0, 1},
+			shed:              0,
+			expectedAggregate: false,
+		},
+		{
+			name:              "aggregate",
+			opt:               multi.WithRunnerAggregateAcceptRate(rate),
+			allowed:           []int{0, 1},
+			shed:              1,
+			expectedAggregate: true,
+		},
+	}
+
+	for _, subtest := range subtests {
+		subtest := subtest
+
+		t.Run(subtest.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				sources = []netx.Listener{listenerx.NewInternal(0), listenerx.NewInternal(0)}
+				events  = make(chan multi.RunnerEvent, 10)
+			)
+
+			ml := multi.NewListener(
+				sources,
+				subtest.opt,
+				multi.WithRunnerEventHandler(func(re multi.RunnerEvent) { events <- re }),
+			)
+
+			for _, runner := range ml.Runners() {
+				go runner.Run()
+				defer runner.Close(context.Background())
+			}
+
+			for _, idx := range subtest.allowed {
+				conn, err := sources[idx].Dial()
+				require.NoError(t, err)
+				defer conn.Close()
+
+				accepted, err := ml.Accept()
+				require.NoError(t, err)
+				accepted.Close()
+			}
+
+			conn, err := sources[subtest.shed].Dial()
+			require.NoError(t, err)
+			defer conn.Close()
+
+			_, err = conn.Read(make([]byte, 1))
+			assert.ErrorIs(t, err, io.EOF, "expected shed connection closed")
+
+			event := <-events
+			require.IsType(t, multi.RunnerEventAcceptRateLimited{}, event)
+			assert.Equal(t, subtest.expectedAggregate, event.(multi.RunnerEventAcceptRateLimited).Aggregate)
+			assert.Equal(t, sources[subtest.shed].Addr(), event.Addr())
+			assert.Empty(t, events)
+		})
+	}
+}
+
+func TestListenerConnTimeouts(t *testing.T) {
+	subtests := []struct {
+		name     string
+		timeouts multi.ConnTimeoutParams
+		chatty   bool
+		expected multi.ConnTimeoutReason
+	}{
+		{
+			name:     "read idle",
+			timeouts: multi.ConnTimeoutParams{ReadIdle: 50 * time.Millisecond},
+			chatty:   false,
+			expected: multi.ConnTimeoutReadIdle,
+		},
+		{
+			name:     "write idle",
+			timeouts: multi.ConnTimeoutParams{ReadIdle: time.Hour, WriteIdle: 50 * time.Millisecond},
+			chatty:   true,
+			expected: multi.ConnTimeoutWriteIdle,
+		},
+		{
+			name:     "max lifetime",
+			timeouts: multi.ConnTimeoutParams{ReadIdle: 50 * time.Millisecond, MaxLifetime: 150 * time.Millisecond},
+			chatty:   true,
+			expected: multi.ConnTimeoutMaxLifetime,
+		},
+	}
+
+	for _, subtest := range subtests {
+		subtest := subtest
+
+		t.Run(subtest.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				source = listenerx.NewInternal(0)
+				events = make(chan multi.RunnerEvent, 10)
+			)
+
+			ml := multi.NewListener(
+				[]netx.Listener{source},
+				multi.WithRunnerConnTimeouts(subtest.timeouts),
+				multi.WithRunnerEventHandler(func(re multi.RunnerEvent) { events <- re }),
+			)
+
+			for _, runner := range ml.Runners() {
+				go runner.Run()
+				defer runner.Close(context.Background())
+			}
+
+			conn, err := source.Dial()
+			require.NoError(t, err)
+			defer conn.Close()
+
+			accepted, err := ml.Accept()
+			require.NoError(t, err)
+
+			start := time.Now()
+
+			// Keep the connection busy in one direction only
+			if subtest.chatty {
+				go func() {
+					for {
+						if _, err := conn.Write([]byte("x")); err != nil {
+							return
+						}
+						time.Sleep(5 * time.Millisecond)
+					}
+				}()
+			}
+
+			_, err = io.Copy(io.Discard, accepted)
+			assert.ErrorIs(t, err, net.ErrClosed)
+
+			event := <-events
+			require.IsType(t, multi.RunnerEventConnTimeout{}, event)
+			assert.Equal(t, subtest.expected, event.(multi.RunnerEventConnTimeout).Reason)
+			assert.Equal(t, source.Addr(), event.Addr())
+
+			if subtest.expected == multi.ConnTimeoutMaxLifetime {
+				assert.GreaterOrEqual(t, int64(time.Since(start)), int64(subtest.timeouts.MaxLifetime))
+			}
+
+			assert.Equal(t, int64(0), ml.Stats().Active)
+		})
+	}
+}
+
+func TestListenerDrain(t *testing.T) {
+	t.Run("untracked", func(t *testing.T) {
+		t.Parallel()
+
+		ml := multi.NewListener([]netx.Listener{listenerx.NewInternal(0)})
+
+		_, err := ml.Drain(context.Background())
+		assert.Error(t, err)
+	})
+
+	t.Run("tracked", func(t *testing.T) {
+		t.Parallel()
+
+		source := listenerx.NewInternal(0)
+		ml := multi.NewListener([]netx.Listener{source}, multi.WithRunnerConnTracking(true))
+
+		runners := ml.Runners()
+		for _, runner := range runners {
+			go runner.Run()
+		}
+
+		var clients, accepted []net.Conn
+		for i := 0; i < 3; i++ {
+			client, err := source.Dial()
+			require.NoError(t, err)
+			defer client.Close()
+
+			conn, err := ml.Accept()
+			require.NoError(t, err)
+
+			clients, accepted = append(clients, client), append(accepted, conn)
+		}
+
+		for _, runner := range runners {
+			require.NoError(t, runner.Close(context.Background()))
+		}
+
+		// One closed before draining, one during, one left to be forced
+		accepted[0].Close()
+		time.AfterFunc(20*time.Millisecond, func() { accepted[1].Close() })
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		res, err := ml.Drain(ctx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, multi.DrainResult{Drained: 1, Forced: 1}, res)
+
+		_, err = clients[2].Read(make([]byte, 1))
+		assert.ErrorIs(t, err, io.EOF, "expected forced close")
+
+		res, err = ml.Drain(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, multi.DrainResult{}, res)
+	})
+}
+
+func testTLSConfig(t *testing.T) *tls.Config {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "multi test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert := tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+	return &tls.Config{Certificates: []tls.Certificate{cert}}
+}
+
+func TestListenerTLSTracked(t *testing.T) {
+	source := listenerx.NewTLS(listenerx.NewInternal(0), testTLSConfig(t))
+	ml := multi.NewListener([]netx.Listener{source}, multi.WithRunnerConnTracking(true))
+
+	for _, runner := range ml.Runners() {
+		go runner.Run()
+		defer runner.Close(context.Background())
+	}
+
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		conn, err := ml.Accept()
+		if err != nil {
+			close(accepted)
+			return
+		}
+
+		// Echo a single byte, completing the handshake along the way
+		buf := make([]byte, 1)
+		if _, err := io.ReadFull(conn, buf); err == nil {
+			conn.Write(buf)
+		}
+		accepted <- conn
+	}()
+
+	client, err := source.Dial()
+	require.NoError(t, err)
+	defer client.Close()
+
+	_, err = client.Write([]byte("x"))
+	require.NoError(t, err)
+
+	buf := make([]byte, 1)
+	_, err = io.ReadFull(client, buf)
+	require.NoError(t, err)
+	assert.Equal(t, "x", string(buf))
+
+	conn, ok := <-accepted
+	require.True(t, ok, "expected accepted connection")
+	defer conn.Close()
+
+	tlsConn, ok := conn.(*tls.Conn)
+	require.True(t, ok, "expected *tls.Conn, got %T", conn)
+	assert.True(t, tlsConn.ConnectionState().HandshakeComplete)
+
+	stats := ml.Stats()
+	assert.Equal(t, int64(1), stats.Active)
+	assert.Greater(t, stats.BytesRead, int64(1), "expected handshake bytes counted")
+	assert.Greater(t, stats.BytesWritten, int64(1), "expected handshake bytes counted")
+}
+
+type testShardedListener struct {
+	netx.Listener
+	shards []net.Listener
+}
+
+func (tsl testShardedListener) Shards() []net.Listener { return tsl.shards }
+
+func TestListenerTLSSharded(t *testing.T) {
+	var (
+		config = testTLSConfig(t)
+		shards = []net.Listener{listenerx.NewInternal(0), listenerx.NewInternal(0)}
+		source = listenerx.NewTLS(testShardedListener{Listener: shards[0].(netx.Listener), shards: shards}, config)
+		ml     = multi.NewListener([]netx.Listener{source})
+	)
+
+	// Each shard keeps an accept loop of its own beneath TLS
+	runners := ml.Runners()
+	require.Len(t, runners, len(shards))
+
+	for _, runner := range runners {
+		go runner.Run()
+		defer runner.Close(context.Background())
+	}
+
+	for i, shard := range shards {
+		raw, err := shard.(netx.Listener).Dial()
+		require.NoError(t, err)
+
+		client := tls.Client(raw, &tls.Config{InsecureSkipVerify: true})
+		defer client.Close()
+
+		accepted := make(chan net.Conn, 1)
+		go func() {
+			conn, err := ml.Accept()
+			if err != nil {
+				close(accepted)
+				return
+			}
+
+			buf := make([]byte, 1)
+			if _, err := io.ReadFull(conn, buf); err == nil {
+				conn.Write(buf)
+			}
+			accepted <- conn
+		}()
+
+		_, err = client.Write([]byte("x"))
+		require.NoError(t, err, "shard %d", i)
+
+		buf := make([]byte, 1)
+		_, err = io.ReadFull(client, buf)
+		require.NoError(t, err, "shard %d", i)
+
+		conn, ok := <-accepted
+		require.True(t, ok, "expected accepted connection")
+		defer conn.Close()
+
+		_, ok = conn.(*tls.Conn)
+		assert.True(t, ok, "expected *tls.Conn from shard %d, got %T", i, conn)
+	}
+}
@@ -2,18 +2,24 @@ package multi
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"net"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/oligarch316/go-netx/limitx"
 	"github.com/oligarch316/go-netx/listenerx/retry"
+	"github.com/oligarch316/go-netx/tracex"
 )
 
 var (
 	errMergeListenerClosed = errors.New("source listener closed")
 	errMergeRunnerClosed   = errors.New("runner closed")
+	errAcceptRateLimited   = errors.New("accept rate limited")
+	errConnTimeout         = errors.New("connection closed")
 )
 
 const mergeAddrNetwork = "multi"
@@ -27,16 +33,20 @@ type mergeListener struct {
 	connChan  chan net.Conn
 	closeChan chan struct{}
 	closeOnce sync.Once
+
+	// limiter is shared by every runner feeding this listener
+	limiter *limitx.Bucket
 }
 
-func newMergeListener() *mergeListener {
+func newMergeListener(aggregateRate limitx.Rate) *mergeListener {
 	return &mergeListener{
 		connChan:  make(chan net.Conn),
 		closeChan: make(chan struct{}),
+		limiter:   limitx.NewBucket(aggregateRate),
 	}
 }
 
-func (mergeListener) Addr() net.Addr { return mergeAddr{} }
+func (*mergeListener) Addr() net.Addr { return mergeAddr{} }
 
 func (ml *mergeListener) Accept() (net.Conn, error) {
 	select {
@@ -56,24 +66,46 @@ func (ml *mergeListener) Close() error {
 type RunnerParams struct {
 	AcceptRetryDelay retry.DelayFunc
 	EventHandler     RunnerEventHandler
+	TrackConns       bool
+	Tracer           tracex.Tracer
+
+	// AcceptRate limits connections accepted from each source listener, and
+	// AggregateAcceptRate those accepted across all of them. Connections
+	// beyond either limit are closed immediately.
+	AcceptRate, AggregateAcceptRate limitx.Rate
+
+	// ConnTimeouts are enforced on every accepted connection.
+	ConnTimeouts ConnTimeoutParams
 }
 
 // MergeRunner TODO.
 type MergeRunner struct {
 	params RunnerParams
 
-	source net.Listener
-	sink   *mergeListener
+	source  net.Listener
+	tls     *tls.Config
+	sink    *mergeListener
+	stats   *connStats
+	limiter *limitx.Bucket
 
 	doneChan  chan struct{}
 	closeChan chan struct{}
 }
 
-func newMergeRunner(params RunnerParams, source net.Listener, sink *mergeListener) *MergeRunner {
+// newMergeRunner accepts from source, serving TLS over its connections given a
+// tlsConfig.
+func newMergeRunner(params RunnerParams, source net.Listener, tlsConfig *tls.Config, sink *mergeListener, stats *connStats) *MergeRunner {
+	if params.Tracer == nil {
+		params.Tracer = tracex.Noop
+	}
+
 	return &MergeRunner{
 		params:    params,
 		source:    source,
+		tls:       tlsConfig,
 		sink:      sink,
+		stats:     stats,
+		limiter:   limitx.NewBucket(params.AcceptRate),
 		doneChan:  make(chan struct{}),
 		closeChan: make(chan struct{}),
 	}
@@ -126,27 +158,94 @@ func (mr *MergeRunner) Run() error {
 
 		delay.Reset()
 
-		select {
-		case mr.sink.connChan <- conn:
-			// Connection was successfully handed off, continue
-		case <-mr.closeChan:
-			// Runner was closed
-			if err := conn.Close(); err != nil {
-				mr.sendEvent(RunnerEventUnprocessedConnectionCloseError{
-					runnerEvent: runnerEvent{addr: mr.Addr(), err: err},
-				})
-			}
-			return fmt.Errorf("unprocessed connection: %w", errMergeRunnerClosed)
-		case <-mr.sink.closeChan:
-			// Target merge listener was closed
-			if err := conn.Close(); err != nil {
-				mr.sendEvent(RunnerEventUnprocessedConnectionCloseError{
-					runnerEvent: runnerEvent{addr: mr.Addr(), err: err},
-				})
-			}
-			return fmt.Errorf("unprocessed connection: %w", errMergeListenerClosed)
+		if mr.shed(conn) {
+			continue
+		}
+
+		atomic.AddInt64(&mr.stats.accepted, 1)
+
+		if mr.params.TrackConns || mr.params.ConnTimeouts.enabled() {
+			conn = newTrackedConn(conn, mr.stats, mr.params.ConnTimeouts, mr.connTimeoutHandler(conn))
 		}
+
+		if mr.tls != nil {
+			conn = tls.Server(conn, mr.tls)
+		}
+
+		if err := mr.handoff(conn); err != nil {
+			return err
+		}
+	}
+}
+
+func (mr *MergeRunner) connTimeoutHandler(conn net.Conn) func(ConnTimeoutReason) {
+	var (
+		addr       = mr.Addr()
+		remoteAddr = conn.RemoteAddr()
+	)
+
+	return func(reason ConnTimeoutReason) {
+		mr.sendEvent(RunnerEventConnTimeout{
+			runnerEvent: runnerEvent{addr: addr, err: errConnTimeout},
+			Reason:      reason,
+			RemoteAddr:  remoteAddr,
+		})
+	}
+}
+
+// shed closes conn if it exceeds either the source or aggregate accept rate.
+// Neither limit is charged for a connection the other sheds.
+func (mr *MergeRunner) shed(conn net.Conn) bool {
+	ok, idx, _ := limitx.AllowAll(mr.limiter, mr.sink.limiter)
+	if ok {
+		return false
+	}
+
+	mr.sendEvent(RunnerEventAcceptRateLimited{
+		runnerEvent: runnerEvent{addr: mr.Addr(), err: errAcceptRateLimited},
+		Aggregate:   idx == 1,
+	})
+
+	if err := conn.Close(); err != nil {
+		mr.sendEvent(RunnerEventUnprocessedConnectionCloseError{
+			runnerEvent: runnerEvent{addr: mr.Addr(), err: err},
+		})
+	}
+
+	return true
+}
+
+func (mr *MergeRunner) handoff(conn net.Conn) error {
+	_, span := mr.params.Tracer.Start(
+		context.Background(), "multi.handoff",
+		tracex.Attr("net.sock.addr", mr.Addr().String()),
+		tracex.Attr("net.peer.addr", conn.RemoteAddr().String()),
+	)
+	defer span.End()
+
+	var err error
+
+	select {
+	case mr.sink.connChan <- conn:
+		// Connection was successfully handed off
+		return nil
+	case <-mr.closeChan:
+		// Runner was closed
+		err = fmt.Errorf("unprocessed connection: %w", errMergeRunnerClosed)
+	case <-mr.sink.closeChan:
+		// Target merge listener was closed
+		err = fmt.Errorf("unprocessed connection: %w", errMergeListenerClosed)
 	}
+
+	span.RecordError(err)
+
+	if closeErr := conn.Close(); closeErr != nil {
+		mr.sendEvent(RunnerEventUnprocessedConnectionCloseError{
+			runnerEvent: runnerEvent{addr: mr.Addr(), err: closeErr},
+		})
+	}
+
+	return err
 }
 
 // Close TODO.
@@ -1,8 +1,12 @@
 package multi
 
 import (
+	"time"
+
 	"github.com/oligarch316/go-netx/addressx"
+	"github.com/oligarch316/go-netx/limitx"
 	"github.com/oligarch316/go-netx/listenerx/retry"
+	"github.com/oligarch316/go-netx/tracex"
 )
 
 // WithRunnerEventHandler TODO.
@@ -10,11 +14,64 @@ func WithRunnerEventHandler(handler RunnerEventHandler) ListenerOption {
 	return func(p *ListenerParams) { p.Runner.EventHandler = handler }
 }
 
+// WithRunnerEventRecorder TODO.
+func WithRunnerEventRecorder(recorder *EventRecorder) ListenerOption {
+	return func(p *ListenerParams) {
+		prev := p.Runner.EventHandler
+		p.Runner.EventHandler = func(re RunnerEvent) {
+			if prev != nil {
+				prev(re)
+			}
+			recorder.Record(re)
+		}
+	}
+}
+
 // WithRunnerRetryDelay TODO.
 func WithRunnerRetryDelay(delayFunc retry.DelayFunc) ListenerOption {
 	return func(p *ListenerParams) { p.Runner.AcceptRetryDelay = delayFunc }
 }
 
+// WithRunnerConnTracking TODO.
+func WithRunnerConnTracking(track bool) ListenerOption {
+	return func(p *ListenerParams) { p.Runner.TrackConns = track }
+}
+
+// WithRunnerTracer TODO.
+func WithRunnerTracer(tracer tracex.Tracer) ListenerOption {
+	return func(p *ListenerParams) { p.Runner.Tracer = tracer }
+}
+
+// WithRunnerAcceptRate TODO.
+func WithRunnerAcceptRate(rate limitx.Rate) ListenerOption {
+	return func(p *ListenerParams) { p.Runner.AcceptRate = rate }
+}
+
+// WithRunnerAggregateAcceptRate TODO.
+func WithRunnerAggregateAcceptRate(rate limitx.Rate) ListenerOption {
+	return func(p *ListenerParams) { p.Runner.AggregateAcceptRate = rate }
+}
+
+// WithRunnerConnTimeouts TODO.
+func WithRunnerConnTimeouts(timeouts ConnTimeoutParams) ListenerOption {
+	return func(p *ListenerParams) { p.Runner.ConnTimeouts = timeouts }
+}
+
+// WithRunnerReadIdleTimeout TODO.
+func WithRunnerReadIdleTimeout(d time.Duration) ListenerOption {
+	return func(p *ListenerParams) { p.Runner.ConnTimeouts.ReadIdle = d }
+}
+
+// WithRunnerWriteIdleTimeout TODO.
+func WithRunnerWriteIdleTimeout(d time.Duration) ListenerOption {
+	return func(p *ListenerParams) { p.Runner.ConnTimeouts.WriteIdle = d }
+}
+
+// WithRunnerMaxConnLifetime TODO.
+func WithRunnerMaxConnLifetime(d time.Duration) ListenerOption {
+	return func(p *ListenerParams) { p.Runner.ConnTimeouts.MaxLifetime = d }
+}
+
 // WithDialerAddressOrdering TODO.
 func WithDialerAddressOrdering(ordering addressx.Ordering) ListenerOption {
 	return func(p *ListenerParams) { p.Dialer.AddressOrdering = ordering }
@@ -24,3 +81,23 @@ func WithDialerAddressOrdering(ordering addressx.Ordering) ListenerOption {
 func WithDialerStrategy(strategy DialStrategy) ListenerOption {
 	return func(p *ListenerParams) { p.Dialer.Strategy = strategy }
 }
+
+// WithDialerRetryDelay TODO.
+func WithDialerRetryDelay(delayFunc retry.DelayFunc) ListenerOption {
+	return func(p *ListenerParams) { p.Dialer.RetryDelay = delayFunc }
+}
+
+// WithDialerRetryMaxAttempts TODO.
+func WithDialerRetryMaxAttempts(n int) ListenerOption {
+	return func(p *ListenerParams) { p.Dialer.RetryMaxAttempts = n }
+}
+
+// WithDialerTracer TODO.
+func WithDialerTracer(tracer tracex.Tracer) ListenerOption {
+	return func(p *ListenerParams) { p.Dialer.Tracer = tracer }
+}
+
+// WithTracer TODO.
+func WithTracer(tracer tracex.Tracer) ListenerOption {
+	return func(p *ListenerParams) { p.Dialer.Tracer, p.Runner.Tracer = tracer, tracer }
+}
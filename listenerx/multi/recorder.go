@@ -0,0 +1,54 @@
+package multi
+
+import (
+	"sync"
+	"time"
+)
+
+// RecordedEvent TODO.
+type RecordedEvent struct {
+	Time  time.Time
+	Event RunnerEvent
+}
+
+// EventRecorder TODO.
+type EventRecorder struct {
+	mu     sync.Mutex
+	events []RecordedEvent
+	next   int
+	full   bool
+}
+
+// NewEventRecorder TODO.
+func NewEventRecorder(size int) *EventRecorder {
+	if size < 1 {
+		size = 1
+	}
+	return &EventRecorder{events: make([]RecordedEvent, size)}
+}
+
+// Record TODO.
+func (er *EventRecorder) Record(event RunnerEvent) {
+	er.mu.Lock()
+	defer er.mu.Unlock()
+
+	er.events[er.next] = RecordedEvent{Time: time.Now(), Event: event}
+
+	if er.next++; er.next == len(er.events) {
+		er.next, er.full = 0, true
+	}
+}
+
+// Events returns recorded events, oldest first.
+func (er *EventRecorder) Events() []RecordedEvent {
+	er.mu.Lock()
+	defer er.mu.Unlock()
+
+	if !er.full {
+		return append([]RecordedEvent(nil), er.events[:er.next]...)
+	}
+
+	res := make([]RecordedEvent, 0, len(er.events))
+	res = append(res, er.events[er.next:]...)
+	return append(res, er.events[:er.next]...)
+}
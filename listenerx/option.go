@@ -0,0 +1,82 @@
+package listenerx
+
+import (
+	"os"
+	"time"
+)
+
+// ----- Internal Options
+
+// WithInternalLatency TODO.
+func WithInternalLatency(latency time.Duration) InternalOption {
+	return func(p *InternalParams) { p.Latency = latency }
+}
+
+// WithInternalBandwidth TODO.
+func WithInternalBandwidth(bytesPerSecond int64) InternalOption {
+	return func(p *InternalParams) { p.Bandwidth = bytesPerSecond }
+}
+
+// ----- Unix Options
+
+// WithUnixFileMode TODO.
+func WithUnixFileMode(mode os.FileMode) UnixOption {
+	return func(p *UnixParams) { p.FileMode = mode }
+}
+
+// WithUnixOwner TODO.
+func WithUnixOwner(uid, gid int) UnixOption {
+	return func(p *UnixParams) { p.UID, p.GID = uid, gid }
+}
+
+// WithUnixMkdirAll TODO.
+func WithUnixMkdirAll(mode os.FileMode) UnixOption {
+	return func(p *UnixParams) { p.DirMode = mode }
+}
+
+// WithUnixRemoveStale TODO.
+func WithUnixRemoveStale(remove bool) UnixOption {
+	return func(p *UnixParams) { p.RemoveStale = remove }
+}
+
+// ----- Socket Options
+
+// WithSocketReuseAddr TODO.
+func WithSocketReuseAddr(reuse bool) SocketOption {
+	return func(p *SocketParams) { p.ReuseAddr = reuse }
+}
+
+// WithSocketReusePort TODO.
+func WithSocketReusePort(reuse bool) SocketOption {
+	return func(p *SocketParams) { p.ReusePort = reuse }
+}
+
+// WithSocketNoDelay TODO.
+func WithSocketNoDelay(noDelay bool) SocketOption {
+	return func(p *SocketParams) { p.NoDelay = noDelay }
+}
+
+// WithSocketKeepAlive TODO.
+func WithSocketKeepAlive(period time.Duration) SocketOption {
+	return func(p *SocketParams) { p.KeepAlive = period }
+}
+
+// WithSocketFastOpen TODO.
+func WithSocketFastOpen(queueLen int) SocketOption {
+	return func(p *SocketParams) { p.FastOpen = queueLen }
+}
+
+// WithSocketBuffers TODO.
+func WithSocketBuffers(send, recv int) SocketOption {
+	return func(p *SocketParams) { p.SendBuffer, p.RecvBuffer = send, recv }
+}
+
+// WithSocketBacklog TODO.
+func WithSocketBacklog(backlog int) SocketOption {
+	return func(p *SocketParams) { p.Backlog = backlog }
+}
+
+// WithSocketShards TODO.
+func WithSocketShards(n int) SocketOption {
+	return func(p *SocketParams) { p.Shards = n }
+}
@@ -0,0 +1,54 @@
+package listenerx
+
+import (
+	"context"
+	"net"
+	"os"
+
+	"github.com/oligarch316/go-netx"
+)
+
+type basicPacketListener struct {
+	net.PacketConn
+	dialer net.Dialer
+}
+
+// NewBasicPacket TODO.
+func NewBasicPacket(pc net.PacketConn) netx.PacketListener {
+	return &basicPacketListener{PacketConn: pc}
+}
+
+// NewPacket TODO.
+func NewPacket(network, address string) (netx.PacketListener, error) {
+	pc, err := net.ListenPacket(network, address)
+	if err != nil {
+		return nil, err
+	}
+
+	if network == "unixgram" && !unixIsAbstract(address) {
+		// Unlike stream listeners, datagram sockets never unlink on close
+		return &unixgramPacketListener{basicPacketListener{PacketConn: pc}}, nil
+	}
+
+	return NewBasicPacket(pc), nil
+}
+
+func (bpl *basicPacketListener) Dial() (net.Conn, error) {
+	addr := bpl.LocalAddr()
+	return bpl.dialer.Dial(addr.Network(), addr.String())
+}
+
+func (bpl *basicPacketListener) DialContext(ctx context.Context) (net.Conn, error) {
+	addr := bpl.LocalAddr()
+	return bpl.dialer.DialContext(ctx, addr.Network(), addr.String())
+}
+
+type unixgramPacketListener struct{ basicPacketListener }
+
+func (upl *unixgramPacketListener) Close() error {
+	err := upl.PacketConn.Close()
+	if rmErr := os.Remove(upl.LocalAddr().String()); err == nil && !os.IsNotExist(rmErr) {
+		err = rmErr
+	}
+	return err
+}
@@ -0,0 +1,121 @@
+package listenerx
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+
+	"github.com/oligarch316/go-netx"
+)
+
+// Sharded TODO.
+type Sharded interface {
+	netx.Listener
+	Shards() []net.Listener
+}
+
+type shardAccept struct {
+	conn net.Conn
+	err  error
+}
+
+type shardListener struct {
+	*socketListener
+
+	shards []*socketListener
+
+	acceptOnce sync.Once
+	acceptChan chan shardAccept
+	closeOnce  sync.Once
+	closeChan  chan struct{}
+}
+
+// listenShards opens a set of SO_REUSEPORT sockets bound to the same address,
+// leaving the kernel to distribute incoming connections between them.
+func listenShards(ctx context.Context, network, address string, params SocketParams) (netx.Listener, error) {
+	params.ReusePort = true
+
+	first, err := listenSocket(ctx, network, address, params)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &shardListener{
+		socketListener: first,
+		shards:         []*socketListener{first},
+		acceptChan:     make(chan shardAccept),
+		closeChan:      make(chan struct{}),
+	}
+
+	// Use the resolved address of the first shard in case of an ephemeral port
+	for i := 1; i < params.Shards; i++ {
+		shard, err := listenSocket(ctx, network, first.Addr().String(), params)
+		if err != nil {
+			res.Close()
+			return nil, err
+		}
+
+		res.shards = append(res.shards, shard)
+	}
+
+	return res, nil
+}
+
+func (sl *shardListener) Shards() []net.Listener {
+	res := make([]net.Listener, len(sl.shards))
+	for i, shard := range sl.shards {
+		res[i] = shard
+	}
+	return res
+}
+
+func (sl *shardListener) acceptLoop(shard net.Listener) {
+	for {
+		conn, err := shard.Accept()
+
+		select {
+		case sl.acceptChan <- shardAccept{conn: conn, err: err}:
+		case <-sl.closeChan:
+			if conn != nil {
+				conn.Close()
+			}
+			return
+		}
+
+		if errors.Is(err, net.ErrClosed) {
+			return
+		}
+	}
+}
+
+func (sl *shardListener) Accept() (net.Conn, error) {
+	sl.acceptOnce.Do(func() {
+		for _, shard := range sl.shards {
+			go sl.acceptLoop(shard)
+		}
+	})
+
+	select {
+	case item := <-sl.acceptChan:
+		return item.conn, item.err
+	case <-sl.closeChan:
+		return nil, net.ErrClosed
+	}
+}
+
+func (sl *shardListener) Close() error {
+	var res error
+
+	sl.closeOnce.Do(func() {
+		close(sl.closeChan)
+
+		for _, shard := range sl.shards {
+			if err := shard.Close(); err != nil && res == nil && !errors.Is(err, net.ErrClosed) {
+				res = err
+			}
+		}
+	})
+
+	return res
+}
@@ -0,0 +1,197 @@
+package listenerx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/oligarch316/go-netx"
+)
+
+var errSocketOptionUnsupported = errors.New("listenerx: socket option unsupported on this platform")
+
+// SocketOption TODO.
+type SocketOption func(*SocketParams)
+
+// SocketParams TODO.
+type SocketParams struct {
+	ReuseAddr, ReusePort   bool
+	NoDelay                bool
+	KeepAlive              time.Duration
+	FastOpen               int
+	SendBuffer, RecvBuffer int
+	Backlog                int
+	Shards                 int
+}
+
+func defaultSocketParams() SocketParams {
+	return SocketParams{
+		ReuseAddr:  false,
+		ReusePort:  false,
+		NoDelay:    true,
+		KeepAlive:  0,
+		FastOpen:   0,
+		SendBuffer: 0,
+		RecvBuffer: 0,
+		Backlog:    0,
+		Shards:     1,
+	}
+}
+
+func socketIsTCP(network string) bool { return strings.HasPrefix(network, "tcp") }
+
+func socketControl(network string, c syscall.RawConn, f func(uintptr, bool) error) error {
+	var fErr error
+	if err := c.Control(func(fd uintptr) { fErr = f(fd, socketIsTCP(network)) }); err != nil {
+		return err
+	}
+	return fErr
+}
+
+func (sp SocketParams) controlListen(network, _ string, c syscall.RawConn) error {
+	return socketControl(network, c, func(fd uintptr, tcp bool) error { return socketControlListen(fd, tcp, sp) })
+}
+
+func (sp SocketParams) controlDial(network, _ string, c syscall.RawConn) error {
+	return socketControl(network, c, func(fd uintptr, tcp bool) error { return socketControlDial(fd, tcp, sp) })
+}
+
+type socketBufferConn interface {
+	SetReadBuffer(int) error
+	SetWriteBuffer(int) error
+}
+
+func (sp SocketParams) applyConn(conn net.Conn) error {
+	if tc, ok := conn.(*net.TCPConn); ok {
+		if err := tc.SetNoDelay(sp.NoDelay); err != nil {
+			return err
+		}
+	}
+
+	if bc, ok := conn.(socketBufferConn); ok {
+		if sp.RecvBuffer > 0 {
+			if err := bc.SetReadBuffer(sp.RecvBuffer); err != nil {
+				return err
+			}
+		}
+
+		if sp.SendBuffer > 0 {
+			if err := bc.SetWriteBuffer(sp.SendBuffer); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// socketAcceptError is reported as temporary so that a failure to configure a
+// single accepted connection does not bring down an accept loop.
+type socketAcceptError struct{ error }
+
+func (sae socketAcceptError) Unwrap() error { return sae.error }
+func (socketAcceptError) Timeout() bool     { return false }
+func (socketAcceptError) Temporary() bool   { return true }
+func (sae socketAcceptError) Error() string { return fmt.Sprintf("listenerx: socket: %s", sae.error) }
+
+type socketListener struct {
+	net.Listener
+	params SocketParams
+	dialer net.Dialer
+}
+
+// Listen TODO.
+func Listen(ctx context.Context, network, address string, opts ...SocketOption) (netx.Listener, error) {
+	params := defaultSocketParams()
+	for _, opt := range opts {
+		opt(&params)
+	}
+
+	if params.Shards > 1 {
+		return listenShards(ctx, network, address, params)
+	}
+
+	return listenSocket(ctx, network, address, params)
+}
+
+func listenSocket(ctx context.Context, network, address string, params SocketParams) (*socketListener, error) {
+	lc := net.ListenConfig{
+		KeepAlive: params.KeepAlive,
+		Control:   params.controlListen,
+	}
+
+	l, err := lc.Listen(ctx, network, address)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.Backlog > 0 {
+		if err := socketListenBacklog(l, params.Backlog); err != nil {
+			l.Close()
+			return nil, err
+		}
+	}
+
+	return &socketListener{
+		Listener: l,
+		params:   params,
+		dialer: net.Dialer{
+			KeepAlive: params.KeepAlive,
+			Control:   params.controlDial,
+		},
+	}, nil
+}
+
+// socketListenBacklog re-issues listen(2) on an already listening socket with
+// the requested backlog, since net.ListenConfig offers no way to specify one.
+func socketListenBacklog(l net.Listener, backlog int) error {
+	sc, ok := l.(syscall.Conn)
+	if !ok {
+		return fmt.Errorf("%w: backlog", errSocketOptionUnsupported)
+	}
+
+	rc, err := sc.SyscallConn()
+	if err != nil {
+		return err
+	}
+
+	return socketControl(l.Addr().Network(), rc, func(fd uintptr, _ bool) error { return socketSetBacklog(fd, backlog) })
+}
+
+func (sl *socketListener) Accept() (net.Conn, error) {
+	conn, err := sl.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := sl.params.applyConn(conn); err != nil {
+		conn.Close()
+		return nil, socketAcceptError{err}
+	}
+
+	return conn, nil
+}
+
+func (sl *socketListener) Dial() (net.Conn, error) {
+	return sl.DialContext(context.Background())
+}
+
+func (sl *socketListener) DialContext(ctx context.Context) (net.Conn, error) {
+	addr := sl.Addr()
+
+	conn, err := sl.dialer.DialContext(ctx, addr.Network(), addr.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := sl.params.applyConn(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return conn, nil
+}
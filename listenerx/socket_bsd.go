@@ -0,0 +1,16 @@
+//go:build darwin || dragonfly || freebsd || netbsd || openbsd
+// +build darwin dragonfly freebsd netbsd openbsd
+
+package listenerx
+
+import "fmt"
+
+// TCP fast open is either absent or configured system wide on these platforms.
+
+func socketFastOpenListen(int, int) error {
+	return fmt.Errorf("%w: fast open", errSocketOptionUnsupported)
+}
+
+func socketFastOpenDial(int) error {
+	return fmt.Errorf("%w: fast open", errSocketOptionUnsupported)
+}
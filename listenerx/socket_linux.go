@@ -0,0 +1,11 @@
+package listenerx
+
+import "golang.org/x/sys/unix"
+
+func socketFastOpenListen(fd, queueLen int) error {
+	return unix.SetsockoptInt(fd, unix.IPPROTO_TCP, unix.TCP_FASTOPEN, queueLen)
+}
+
+func socketFastOpenDial(fd int) error {
+	return unix.SetsockoptInt(fd, unix.IPPROTO_TCP, unix.TCP_FASTOPEN_CONNECT, 1)
+}
@@ -0,0 +1,71 @@
+package listenerx_test
+
+import (
+	"context"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/oligarch316/go-netx/listenerx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+)
+
+func getsockopt(t *testing.T, conn net.Conn, level, opt int) int {
+	t.Helper()
+
+	sc, ok := conn.(syscall.Conn)
+	require.True(t, ok, "expected syscall conn")
+
+	rc, err := sc.SyscallConn()
+	require.NoError(t, err)
+
+	var (
+		res    int
+		optErr error
+	)
+
+	require.NoError(t, rc.Control(func(fd uintptr) { res, optErr = unix.GetsockoptInt(int(fd), level, opt) }))
+	require.NoError(t, optErr)
+	return res
+}
+
+func TestSocketConnOptions(t *testing.T) {
+	const bufSize = 64 * 1024
+
+	l, err := listenerx.Listen(
+		context.Background(), "tcp", "127.0.0.1:0",
+		listenerx.WithSocketNoDelay(false),
+		listenerx.WithSocketKeepAlive(30*time.Second),
+		listenerx.WithSocketBuffers(bufSize, bufSize),
+		listenerx.WithSocketBacklog(16),
+	)
+	require.NoError(t, err)
+	defer l.Close()
+
+	acceptChan := make(chan net.Conn, 1)
+	go func() {
+		conn, err := l.Accept()
+		assert.NoError(t, err)
+		acceptChan <- conn
+	}()
+
+	client, err := l.Dial()
+	require.NoError(t, err)
+	defer client.Close()
+
+	server := <-acceptChan
+	require.NotNil(t, server)
+	defer server.Close()
+
+	for name, conn := range map[string]net.Conn{"client": client, "server": server} {
+		assert.Equal(t, 0, getsockopt(t, conn, unix.IPPROTO_TCP, unix.TCP_NODELAY), "%s TCP_NODELAY", name)
+		assert.Equal(t, 1, getsockopt(t, conn, unix.SOL_SOCKET, unix.SO_KEEPALIVE), "%s SO_KEEPALIVE", name)
+
+		// Linux doubles requested buffer sizes to account for bookkeeping
+		assert.Equal(t, 2*bufSize, getsockopt(t, conn, unix.SOL_SOCKET, unix.SO_RCVBUF), "%s SO_RCVBUF", name)
+		assert.Equal(t, 2*bufSize, getsockopt(t, conn, unix.SOL_SOCKET, unix.SO_SNDBUF), "%s SO_SNDBUF", name)
+	}
+}
@@ -0,0 +1,31 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd
+
+package listenerx
+
+import "fmt"
+
+func socketControlListen(_ uintptr, _ bool, p SocketParams) error {
+	switch {
+	case p.ReuseAddr:
+		return fmt.Errorf("%w: reuse address", errSocketOptionUnsupported)
+	case p.ReusePort:
+		return fmt.Errorf("%w: reuse port", errSocketOptionUnsupported)
+	case p.FastOpen > 0:
+		return fmt.Errorf("%w: fast open", errSocketOptionUnsupported)
+	}
+
+	// Buffer sizes are applied per connection instead
+	return nil
+}
+
+func socketControlDial(_ uintptr, _ bool, p SocketParams) error {
+	if p.FastOpen > 0 {
+		return fmt.Errorf("%w: fast open", errSocketOptionUnsupported)
+	}
+	return nil
+}
+
+func socketSetBacklog(uintptr, int) error {
+	return fmt.Errorf("%w: backlog", errSocketOptionUnsupported)
+}
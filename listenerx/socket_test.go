@@ -0,0 +1,66 @@
+package listenerx_test
+
+import (
+	"context"
+	"runtime"
+	"testing"
+
+	"github.com/oligarch316/go-netx/listenerx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func skipUnlessSocketOptions(t *testing.T) {
+	t.Helper()
+
+	switch runtime.GOOS {
+	case "darwin", "dragonfly", "freebsd", "linux", "netbsd", "openbsd":
+	default:
+		t.Skip("socket options only supported on unix")
+	}
+}
+
+func TestSocketReusePort(t *testing.T) {
+	skipUnlessSocketOptions(t)
+
+	ctx := context.Background()
+
+	first, err := listenerx.Listen(ctx, "tcp", "127.0.0.1:0", listenerx.WithSocketReusePort(true))
+	require.NoError(t, err)
+	defer first.Close()
+
+	second, err := listenerx.Listen(ctx, "tcp", first.Addr().String(), listenerx.WithSocketReusePort(true))
+	require.NoError(t, err)
+	defer second.Close()
+
+	_, err = listenerx.Listen(ctx, "tcp", first.Addr().String())
+	assert.Error(t, err, "expected listen without reuse port to fail")
+}
+
+func TestSocketShards(t *testing.T) {
+	skipUnlessSocketOptions(t)
+
+	const nShards, nConns = 4, 16
+
+	l, err := listenerx.Listen(context.Background(), "tcp", "127.0.0.1:0", listenerx.WithSocketShards(nShards))
+	require.NoError(t, err)
+	defer l.Close()
+
+	sharded, ok := l.(listenerx.Sharded)
+	require.True(t, ok, "expected sharded listener")
+	require.Len(t, sharded.Shards(), nShards)
+
+	for _, shard := range sharded.Shards() {
+		assert.Equal(t, l.Addr().String(), shard.Addr().String())
+	}
+
+	for i := 0; i < nConns; i++ {
+		client, err := l.Dial()
+		require.NoError(t, err)
+		defer client.Close()
+
+		server, err := l.Accept()
+		require.NoError(t, err)
+		server.Close()
+	}
+}
@@ -0,0 +1,66 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+// +build darwin dragonfly freebsd linux netbsd openbsd
+
+package listenerx
+
+import "golang.org/x/sys/unix"
+
+func socketSetBuffers(fd int, p SocketParams) error {
+	if p.SendBuffer > 0 {
+		if err := unix.SetsockoptInt(fd, unix.SOL_SOCKET, unix.SO_SNDBUF, p.SendBuffer); err != nil {
+			return err
+		}
+	}
+
+	if p.RecvBuffer > 0 {
+		if err := unix.SetsockoptInt(fd, unix.SOL_SOCKET, unix.SO_RCVBUF, p.RecvBuffer); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func socketControlListen(fd uintptr, tcp bool, p SocketParams) error {
+	sfd := int(fd)
+
+	if p.ReuseAddr {
+		if err := unix.SetsockoptInt(sfd, unix.SOL_SOCKET, unix.SO_REUSEADDR, 1); err != nil {
+			return err
+		}
+	}
+
+	if p.ReusePort {
+		if err := unix.SetsockoptInt(sfd, unix.SOL_SOCKET, unix.SO_REUSEPORT, 1); err != nil {
+			return err
+		}
+	}
+
+	// Set on the listening socket so accepted sockets inherit them before the
+	// TCP handshake completes (relevant to window scaling)
+	if err := socketSetBuffers(sfd, p); err != nil {
+		return err
+	}
+
+	if tcp && p.FastOpen > 0 {
+		return socketFastOpenListen(sfd, p.FastOpen)
+	}
+
+	return nil
+}
+
+func socketControlDial(fd uintptr, tcp bool, p SocketParams) error {
+	sfd := int(fd)
+
+	if err := socketSetBuffers(sfd, p); err != nil {
+		return err
+	}
+
+	if tcp && p.FastOpen > 0 {
+		return socketFastOpenDial(sfd)
+	}
+
+	return nil
+}
+
+func socketSetBacklog(fd uintptr, backlog int) error { return unix.Listen(int(fd), backlog) }
@@ -0,0 +1,407 @@
+package listenerx
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/user"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/oligarch316/go-netx"
+)
+
+const (
+	specNetworkSystemd = "systemd"
+
+	specSeparatorAddress = "://"
+	specSeparatorQuery   = "?"
+	specPrefixTLS        = "tls+"
+
+	specOptionSize      = "size"
+	specOptionLatency   = "latency"
+	specOptionBandwidth = "bandwidth"
+
+	specOptionMode  = "mode"
+	specOptionOwner = "owner"
+	specOptionGroup = "group"
+	specOptionMkdir = "mkdir"
+
+	specOptionReuseAddr = "reuseaddr"
+	specOptionReusePort = "reuseport"
+	specOptionNoDelay   = "nodelay"
+	specOptionKeepAlive = "keepalive"
+	specOptionFastOpen  = "fastopen"
+	specOptionSendBuf   = "sndbuf"
+	specOptionRecvBuf   = "rcvbuf"
+	specOptionBacklog   = "backlog"
+	specOptionShards    = "shards"
+	specOptionCert      = "cert"
+	specOptionKey       = "key"
+)
+
+var errInvalidSpec = errors.New("listenerx: invalid spec")
+
+type specNetwork struct {
+	requireAddress bool
+	options        []string
+}
+
+var specSocketOptions = []string{
+	specOptionReuseAddr, specOptionReusePort, specOptionNoDelay, specOptionKeepAlive,
+	specOptionFastOpen, specOptionSendBuf, specOptionRecvBuf, specOptionBacklog,
+	specOptionShards,
+}
+
+var specNetworks = map[string]specNetwork{
+	"tcp":              {requireAddress: true, options: specSocketOptions},
+	"tcp4":             {requireAddress: true, options: specSocketOptions},
+	"tcp6":             {requireAddress: true, options: specSocketOptions},
+	"unix":             {requireAddress: true, options: []string{specOptionMode, specOptionOwner, specOptionGroup, specOptionMkdir}},
+	InternalNetwork:    {requireAddress: false, options: []string{specOptionSize, specOptionLatency, specOptionBandwidth}},
+	specNetworkSystemd: {requireAddress: true},
+}
+
+var specTLSOptions = []string{specOptionCert, specOptionKey}
+
+// Spec TODO.
+type Spec struct {
+	TLS     bool
+	Network string
+	Address string
+	Options url.Values
+}
+
+// ParseSpec TODO.
+func ParseSpec(s string) (Spec, error) {
+	var (
+		res  Spec
+		rest = s
+	)
+
+	if idx := strings.Index(rest, specSeparatorQuery); idx >= 0 {
+		options, err := url.ParseQuery(rest[idx+len(specSeparatorQuery):])
+		if err != nil {
+			return res, fmt.Errorf("%w '%s': %s", errInvalidSpec, s, err)
+		}
+		res.Options, rest = options, rest[:idx]
+	}
+
+	if idx := strings.Index(rest, specSeparatorAddress); idx >= 0 {
+		res.Address, rest = rest[idx+len(specSeparatorAddress):], rest[:idx]
+	}
+
+	if strings.HasPrefix(rest, specPrefixTLS) {
+		res.TLS, rest = true, rest[len(specPrefixTLS):]
+	}
+
+	res.Network = rest
+
+	if err := res.validate(); err != nil {
+		return res, fmt.Errorf("%w '%s': %s", errInvalidSpec, s, err)
+	}
+
+	return res, nil
+}
+
+func (s Spec) validate() error {
+	network, ok := specNetworks[s.Network]
+	if !ok {
+		return fmt.Errorf("unknown network '%s'", s.Network)
+	}
+
+	if network.requireAddress && s.Address == "" {
+		return fmt.Errorf("missing address for network '%s'", s.Network)
+	}
+
+	allowed := make(map[string]bool)
+	for _, name := range network.options {
+		allowed[name] = true
+	}
+
+	if s.TLS {
+		for _, name := range specTLSOptions {
+			if s.Options.Get(name) == "" {
+				return fmt.Errorf("missing tls option '%s'", name)
+			}
+			allowed[name] = true
+		}
+	}
+
+	for name, values := range s.Options {
+		if !allowed[name] {
+			return fmt.Errorf("unknown option '%s' for network '%s'", name, s.Network)
+		}
+
+		if len(values) != 1 {
+			return fmt.Errorf("option '%s' specified %d times", name, len(values))
+		}
+	}
+
+	if _, err := s.intOption(specOptionSize, 10, 0); err != nil {
+		return err
+	}
+
+	if _, err := s.internalOptions(); err != nil {
+		return err
+	}
+
+	if _, err := s.intOption(specOptionMode, 8, 0); err != nil {
+		return err
+	}
+
+	if _, err := s.intOption(specOptionMkdir, 8, 0); err != nil {
+		return err
+	}
+
+	if _, err := s.socketOptions(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s Spec) internalOptions() ([]InternalOption, error) {
+	var res []InternalOption
+
+	if str := s.Options.Get(specOptionLatency); str != "" {
+		val, err := time.ParseDuration(str)
+		if err != nil {
+			return nil, fmt.Errorf("invalid option '%s': %w", specOptionLatency, err)
+		}
+		res = append(res, WithInternalLatency(val))
+	}
+
+	bandwidth, err := s.intOption(specOptionBandwidth, 10, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(res, WithInternalBandwidth(bandwidth)), nil
+}
+
+func (s Spec) socketOptions() ([]SocketOption, error) {
+	var res []SocketOption
+
+	for _, name := range []string{specOptionReuseAddr, specOptionReusePort, specOptionNoDelay} {
+		str := s.Options.Get(name)
+		if str == "" {
+			continue
+		}
+
+		val, err := strconv.ParseBool(str)
+		if err != nil {
+			return nil, fmt.Errorf("invalid option '%s': %w", name, err)
+		}
+
+		switch name {
+		case specOptionReuseAddr:
+			res = append(res, WithSocketReuseAddr(val))
+		case specOptionReusePort:
+			res = append(res, WithSocketReusePort(val))
+		case specOptionNoDelay:
+			res = append(res, WithSocketNoDelay(val))
+		}
+	}
+
+	if str := s.Options.Get(specOptionKeepAlive); str != "" {
+		val, err := time.ParseDuration(str)
+		if err != nil {
+			return nil, fmt.Errorf("invalid option '%s': %w", specOptionKeepAlive, err)
+		}
+		res = append(res, WithSocketKeepAlive(val))
+	}
+
+	var ints [5]int64
+	for i, name := range []string{specOptionFastOpen, specOptionSendBuf, specOptionRecvBuf, specOptionBacklog, specOptionShards} {
+		val, err := s.intOption(name, 10, 0)
+		if err != nil {
+			return nil, err
+		}
+		ints[i] = val
+	}
+
+	return append(
+		res,
+		WithSocketFastOpen(int(ints[0])),
+		WithSocketBuffers(int(ints[1]), int(ints[2])),
+		WithSocketBacklog(int(ints[3])),
+		WithSocketShards(int(ints[4])),
+	), nil
+}
+
+// unixOptions resolves unix options, looking up owner and group names (as
+// opposed to numeric ids) only at listen time.
+func (s Spec) unixOptions() ([]UnixOption, error) {
+	var (
+		mode, _  = s.intOption(specOptionMode, 8, 0)
+		mkdir, _ = s.intOption(specOptionMkdir, 8, 0)
+		uid, gid = -1, -1
+	)
+
+	if name := s.Options.Get(specOptionOwner); name != "" {
+		id, err := strconv.Atoi(name)
+		if err != nil {
+			u, err := user.Lookup(name)
+			if err != nil {
+				return nil, err
+			}
+			id, _ = strconv.Atoi(u.Uid)
+		}
+		uid = id
+	}
+
+	if name := s.Options.Get(specOptionGroup); name != "" {
+		id, err := strconv.Atoi(name)
+		if err != nil {
+			g, err := user.LookupGroup(name)
+			if err != nil {
+				return nil, err
+			}
+			id, _ = strconv.Atoi(g.Gid)
+		}
+		gid = id
+	}
+
+	return []UnixOption{
+		WithUnixFileMode(os.FileMode(mode)),
+		WithUnixMkdirAll(os.FileMode(mkdir)),
+		WithUnixOwner(uid, gid),
+	}, nil
+}
+
+func (s Spec) intOption(name string, base int, fallback int64) (int64, error) {
+	str := s.Options.Get(name)
+	if str == "" {
+		return fallback, nil
+	}
+
+	res, err := strconv.ParseInt(str, base, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid option '%s': %w", name, err)
+	}
+
+	return res, nil
+}
+
+func (s Spec) String() string {
+	var sb strings.Builder
+
+	if s.TLS {
+		sb.WriteString(specPrefixTLS)
+	}
+
+	sb.WriteString(s.Network)
+
+	if s.Address != "" {
+		sb.WriteString(specSeparatorAddress)
+		sb.WriteString(s.Address)
+	}
+
+	if len(s.Options) > 0 {
+		sb.WriteString(specSeparatorQuery)
+		sb.WriteString(s.Options.Encode())
+	}
+
+	return sb.String()
+}
+
+// Set TODO.
+func (s *Spec) Set(str string) error {
+	res, err := ParseSpec(str)
+	if err != nil {
+		return err
+	}
+
+	*s = res
+	return nil
+}
+
+// Listen TODO.
+func (s Spec) Listen() (netx.Listener, error) {
+	if err := s.validate(); err != nil {
+		return nil, fmt.Errorf("%w '%s': %s", errInvalidSpec, s, err)
+	}
+
+	res, err := s.listen()
+	if err != nil || !s.TLS {
+		return res, err
+	}
+
+	cert, err := tls.LoadX509KeyPair(s.Options.Get(specOptionCert), s.Options.Get(specOptionKey))
+	if err != nil {
+		res.Close()
+		return nil, fmt.Errorf("listenerx: spec '%s': %w", s, err)
+	}
+
+	return NewTLS(res, &tls.Config{Certificates: []tls.Certificate{cert}}), nil
+}
+
+func (s Spec) listen() (netx.Listener, error) {
+	switch s.Network {
+	case InternalNetwork:
+		size, _ := s.intOption(specOptionSize, 10, internalDefaultSize)
+		opts, _ := s.internalOptions()
+		return ListenInternal(s.Address, int(size), opts...)
+	case specNetworkSystemd:
+		return NewSystemd(s.Address)
+	case "unix":
+		opts, err := s.unixOptions()
+		if err != nil {
+			return nil, fmt.Errorf("listenerx: spec '%s': %w", s, err)
+		}
+		return NewUnix(s.Address, opts...)
+	}
+
+	opts, err := s.socketOptions()
+	if err != nil {
+		return nil, fmt.Errorf("listenerx: spec '%s': %w", s, err)
+	}
+
+	return Listen(context.Background(), s.Network, s.Address, opts...)
+}
+
+// SpecList TODO.
+type SpecList []Spec
+
+func (sl SpecList) String() string {
+	strs := make([]string, len(sl))
+	for i, spec := range sl {
+		strs[i] = spec.String()
+	}
+	return strings.Join(strs, ",")
+}
+
+// Set TODO.
+func (sl *SpecList) Set(str string) error {
+	spec, err := ParseSpec(str)
+	if err != nil {
+		return err
+	}
+
+	*sl = append(*sl, spec)
+	return nil
+}
+
+// Listen TODO.
+func (sl SpecList) Listen() ([]netx.Listener, error) {
+	res := make([]netx.Listener, 0, len(sl))
+
+	for _, spec := range sl {
+		l, err := spec.Listen()
+		if err != nil {
+			for _, item := range res {
+				item.Close()
+			}
+			return nil, err
+		}
+
+		res = append(res, l)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,130 @@
+package listenerx_test
+
+import (
+	"flag"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/oligarch316/go-netx/listenerx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSpec(t *testing.T) {
+	subtests := []struct {
+		name, spec string
+		expected   listenerx.Spec
+	}{
+		{
+			name:     "tcp",
+			spec:     "tcp://0.0.0.0:8080",
+			expected: listenerx.Spec{Network: "tcp", Address: "0.0.0.0:8080"},
+		},
+		{
+			name: "unix with mode",
+			spec: "unix:///run/app.sock?mode=0660",
+			expected: listenerx.Spec{
+				Network: "unix",
+				Address: "/run/app.sock",
+				Options: url.Values{"mode": {"0660"}},
+			},
+		},
+		{
+			name: "internal with size",
+			spec: "internal?size=1024",
+			expected: listenerx.Spec{
+				Network: "internal",
+				Options: url.Values{"size": {"1024"}},
+			},
+		},
+		{
+			name:     "internal with name",
+			spec:     "internal://backend",
+			expected: listenerx.Spec{Network: "internal", Address: "backend"},
+		},
+		{
+			name:     "systemd",
+			spec:     "systemd://name",
+			expected: listenerx.Spec{Network: "systemd", Address: "name"},
+		},
+		{
+			name: "tls",
+			spec: "tls+tcp://:443?cert=c.pem&key=k.pem",
+			expected: listenerx.Spec{
+				TLS:     true,
+				Network: "tcp",
+				Address: ":443",
+				Options: url.Values{"cert": {"c.pem"}, "key": {"k.pem"}},
+			},
+		},
+	}
+
+	for _, item := range subtests {
+		subtest := item
+
+		t.Run(subtest.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := listenerx.ParseSpec(subtest.spec)
+			require.NoError(t, err)
+			assert.Equal(t, subtest.expected, actual)
+		})
+	}
+}
+
+func TestParseSpecInvalid(t *testing.T) {
+	subtests := []struct{ name, spec string }{
+		{name: "unknown network", spec: "udp://:53"},
+		{name: "missing address", spec: "tcp"},
+		{name: "unknown option", spec: "tcp://:80?size=1"},
+		{name: "invalid option value", spec: "internal?size=big"},
+		{name: "invalid latency", spec: "internal?latency=slow"},
+		{name: "invalid mode", spec: "unix:///run/app.sock?mode=999"},
+		{name: "repeated option", spec: "internal?size=1&size=2"},
+		{name: "missing tls option", spec: "tls+tcp://:443?cert=c.pem"},
+	}
+
+	for _, item := range subtests {
+		subtest := item
+
+		t.Run(subtest.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := listenerx.ParseSpec(subtest.spec)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestSpecListFlag(t *testing.T) {
+	var (
+		sockPath = filepath.Join(t.TempDir(), "app.sock")
+		specs    listenerx.SpecList
+		fs       = flag.NewFlagSet("test", flag.ContinueOnError)
+	)
+
+	fs.Var(&specs, "listen", "listener spec")
+
+	err := fs.Parse([]string{
+		"-listen", "internal?size=1024",
+		"-listen", "unix://" + sockPath + "?mode=0600",
+	})
+	require.NoError(t, err)
+	require.Len(t, specs, 2)
+
+	ls, err := specs.Listen()
+	require.NoError(t, err)
+
+	for _, l := range ls {
+		defer l.Close()
+	}
+
+	assert.Equal(t, "internal", ls[0].Addr().Network())
+	assert.Equal(t, sockPath, ls[1].Addr().String())
+
+	info, err := os.Stat(sockPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
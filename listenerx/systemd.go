@@ -0,0 +1,48 @@
+package listenerx
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/oligarch316/go-netx"
+)
+
+const systemdListenFDsStart = 3
+
+var errSystemdNoSuchSocket = errors.New("listenerx: systemd: no such socket")
+
+// NewSystemd TODO.
+func NewSystemd(name string) (netx.Listener, error) {
+	if pid := os.Getenv("LISTEN_PID"); pid != strconv.Itoa(os.Getpid()) {
+		return nil, fmt.Errorf("%w: %s (no sockets passed to this process)", errSystemdNoSuchSocket, name)
+	}
+
+	nFDs, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil {
+		return nil, fmt.Errorf("listenerx: systemd: invalid LISTEN_FDS: %w", err)
+	}
+
+	names := strings.Split(os.Getenv("LISTEN_FDNAMES"), ":")
+
+	for i := 0; i < nFDs && i < len(names); i++ {
+		if names[i] != name {
+			continue
+		}
+
+		f := os.NewFile(uintptr(systemdListenFDsStart+i), name)
+		defer f.Close()
+
+		l, err := net.FileListener(f)
+		if err != nil {
+			return nil, fmt.Errorf("listenerx: systemd: socket '%s': %w", name, err)
+		}
+
+		return NewBasic(l), nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", errSystemdNoSuchSocket, name)
+}
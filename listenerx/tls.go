@@ -0,0 +1,93 @@
+package listenerx
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+
+	"github.com/oligarch316/go-netx"
+)
+
+var errTLSUnknownCertificate = errors.New("listenerx: tls: peer certificate does not match listener certificate")
+
+// TLS is implemented by listeners serving TLS over the connections of an
+// inner listener, allowing callers to wrap those connections beneath TLS.
+type TLS interface {
+	netx.Listener
+	Inner() netx.Listener
+	TLSConfig() *tls.Config
+}
+
+type tlsListener struct {
+	netx.Listener
+	serverConfig, clientConfig *tls.Config
+}
+
+// NewTLS TODO.
+func NewTLS(l netx.Listener, config *tls.Config) netx.Listener {
+	return &tlsListener{
+		Listener:     l,
+		serverConfig: config,
+		clientConfig: tlsPinnedClientConfig(config),
+	}
+}
+
+// tlsPinnedClientConfig builds the configuration used when dialing ourselves.
+// Rather than rely on the listener certificate being valid for whatever host
+// name the listener address happens to be, the peer is verified by requiring
+// that it present exactly one of the listener's own certificates.
+func tlsPinnedClientConfig(serverConfig *tls.Config) *tls.Config {
+	var pinned [][]byte
+	for _, cert := range serverConfig.Certificates {
+		if len(cert.Certificate) > 0 {
+			pinned = append(pinned, cert.Certificate[0])
+		}
+	}
+
+	return &tls.Config{
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) > 0 {
+				for _, item := range pinned {
+					if bytes.Equal(rawCerts[0], item) {
+						return nil
+					}
+				}
+			}
+			return errTLSUnknownCertificate
+		},
+	}
+}
+
+func (tl *tlsListener) Inner() netx.Listener   { return tl.Listener }
+func (tl *tlsListener) TLSConfig() *tls.Config { return tl.serverConfig }
+
+func (tl *tlsListener) Accept() (net.Conn, error) {
+	conn, err := tl.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return tls.Server(conn, tl.serverConfig), nil
+}
+
+func (tl *tlsListener) Dial() (net.Conn, error) {
+	return tl.DialContext(context.Background())
+}
+
+func (tl *tlsListener) DialContext(ctx context.Context) (net.Conn, error) {
+	conn, err := tl.Listener.DialContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := tls.Client(conn, tl.clientConfig)
+	if err := res.HandshakeContext(ctx); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return res, nil
+}
@@ -0,0 +1,191 @@
+package listenerx
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/oligarch316/go-netx"
+)
+
+const unixStaleDialTimeout = 100 * time.Millisecond
+
+var (
+	errUnixInUse     = errors.New("listenerx: unix: address in use")
+	errUnixNotSocket = errors.New("listenerx: unix: existing file is not a socket")
+)
+
+// UnixOption TODO.
+type UnixOption func(*UnixParams)
+
+// UnixParams TODO.
+type UnixParams struct {
+	FileMode    os.FileMode
+	UID, GID    int
+	DirMode     os.FileMode
+	RemoveStale bool
+}
+
+func defaultUnixParams() UnixParams {
+	return UnixParams{
+		FileMode:    0,
+		UID:         -1,
+		GID:         -1,
+		DirMode:     0,
+		RemoveStale: true,
+	}
+}
+
+func unixIsAbstract(path string) bool { return strings.HasPrefix(path, "@") }
+
+// unixRemoveStale removes an existing socket file at path, but only if nothing
+// is currently accepting connections on it.
+func unixRemoveStale(path string) error {
+	info, err := os.Lstat(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return nil
+	case err != nil:
+		return err
+	case info.Mode()&os.ModeSocket == 0:
+		return fmt.Errorf("%w: %s", errUnixNotSocket, path)
+	}
+
+	conn, err := net.DialTimeout("unix", path, unixStaleDialTimeout)
+	if err == nil {
+		conn.Close()
+		return fmt.Errorf("%w: %s", errUnixInUse, path)
+	}
+
+	if !errors.Is(err, syscall.ECONNREFUSED) {
+		// Unable to prove the socket is stale, leave it be
+		return fmt.Errorf("%w: %s: %s", errUnixInUse, path, err)
+	}
+
+	return os.Remove(path)
+}
+
+// NewUnix TODO.
+func NewUnix(path string, opts ...UnixOption) (netx.Listener, error) {
+	params := defaultUnixParams()
+	for _, opt := range opts {
+		opt(&params)
+	}
+
+	// Abstract namespace sockets have no associated file to manage
+	if unixIsAbstract(path) {
+		l, err := net.Listen("unix", path)
+		if err != nil {
+			return nil, err
+		}
+		return NewBasic(l), nil
+	}
+
+	if params.DirMode != 0 {
+		if err := os.MkdirAll(filepath.Dir(path), params.DirMode); err != nil {
+			return nil, fmt.Errorf("listenerx: unix: %w", err)
+		}
+	}
+
+	if params.RemoveStale {
+		if err := unixRemoveStale(path); err != nil {
+			return nil, err
+		}
+	}
+
+	if params.FileMode == 0 && params.UID == -1 && params.GID == -1 {
+		l, err := net.ListenUnix("unix", &net.UnixAddr{Name: path, Net: "unix"})
+		if err != nil {
+			return nil, err
+		}
+
+		l.SetUnlinkOnClose(true)
+		return NewBasic(l), nil
+	}
+
+	l, err := unixListenPrivate(path, params)
+	if err != nil {
+		return nil, err
+	}
+	return NewBasic(l), nil
+}
+
+// unixListenPrivate binds a socket within a private directory beside path, so
+// that nobody can connect before its mode and owner are applied, then links it
+// into place. Linking, unlike renaming, fails rather than replace an existing
+// file at path.
+func unixListenPrivate(path string, params UnixParams) (net.Listener, error) {
+	dir, err := os.MkdirTemp(filepath.Dir(path), ".listenerx-")
+	if err != nil {
+		return nil, fmt.Errorf("listenerx: unix: %w", err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	tmpPath := filepath.Join(dir, "s")
+
+	l, err := net.ListenUnix("unix", &net.UnixAddr{Name: tmpPath, Net: "unix"})
+	if err != nil {
+		return nil, err
+	}
+
+	l.SetUnlinkOnClose(false)
+
+	fail := func(err error) (net.Listener, error) {
+		l.Close()
+		return nil, fmt.Errorf("listenerx: unix: %w", err)
+	}
+
+	if params.FileMode != 0 {
+		if err := os.Chmod(tmpPath, params.FileMode); err != nil {
+			return fail(err)
+		}
+	}
+
+	if params.UID != -1 || params.GID != -1 {
+		if err := os.Chown(tmpPath, params.UID, params.GID); err != nil {
+			return fail(err)
+		}
+	}
+
+	if err := os.Link(tmpPath, path); err != nil {
+		if errors.Is(err, fs.ErrExist) {
+			l.Close()
+			return nil, fmt.Errorf("%w: %s", errUnixInUse, path)
+		}
+		return fail(err)
+	}
+
+	return &unixListener{UnixListener: l, addr: &net.UnixAddr{Name: path, Net: "unix"}}, nil
+}
+
+// unixListener reports and, once closed, unlinks the path a socket was linked
+// to rather than the one it was bound to. Accepted connections still report the
+// latter as their local address.
+type unixListener struct {
+	*net.UnixListener
+	addr       *net.UnixAddr
+	unlinkOnce sync.Once
+}
+
+func (ul *unixListener) Addr() net.Addr { return ul.addr }
+
+func (ul *unixListener) Close() error {
+	err := ul.UnixListener.Close()
+
+	// Unlink only once, a later listener may have taken over the path since
+	ul.unlinkOnce.Do(func() {
+		if rmErr := os.Remove(ul.addr.Name); err == nil && rmErr != nil && !os.IsNotExist(rmErr) {
+			err = rmErr
+		}
+	})
+
+	return err
+}
@@ -0,0 +1,118 @@
+package listenerx_test
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/oligarch316/go-netx/listenerx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnixFileManagement(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "dir", "app.sock")
+
+	l, err := listenerx.NewUnix(
+		path,
+		listenerx.WithUnixMkdirAll(0750),
+		listenerx.WithUnixFileMode(0660),
+	)
+	require.NoError(t, err)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0660), info.Mode().Perm())
+	assert.Equal(t, path, l.Addr().String())
+
+	// The socket was bound in a private directory, removed once linked
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "app.sock", entries[0].Name())
+
+	conn, err := l.Dial()
+	require.NoError(t, err)
+	conn.Close()
+
+	require.NoError(t, l.Close())
+
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err), "expected socket file removed on close")
+}
+
+func TestUnixStaleSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.sock")
+
+	// Leave a socket file behind with nothing listening on it
+	stale, err := net.ListenUnix("unix", &net.UnixAddr{Name: path, Net: "unix"})
+	require.NoError(t, err)
+	stale.SetUnlinkOnClose(false)
+	require.NoError(t, stale.Close())
+
+	_, err = listenerx.NewUnix(path, listenerx.WithUnixRemoveStale(false))
+	require.Error(t, err)
+
+	l, err := listenerx.NewUnix(path)
+	require.NoError(t, err)
+	defer l.Close()
+
+	// A live socket must not be removed
+	_, err = listenerx.NewUnix(path)
+	assert.Error(t, err)
+
+	conn, err := l.Dial()
+	require.NoError(t, err)
+	conn.Close()
+}
+
+func TestUnixNotSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.sock")
+	require.NoError(t, os.WriteFile(path, nil, 0600))
+
+	_, err := listenerx.NewUnix(path)
+	assert.Error(t, err)
+
+	_, err = os.Stat(path)
+	assert.NoError(t, err, "expected regular file left in place")
+}
+
+func TestUnixFileModeExisting(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.sock")
+
+	l, err := listenerx.NewUnix(path)
+	require.NoError(t, err)
+	defer l.Close()
+
+	// Setting a mode must not replace a live socket either
+	_, err = listenerx.NewUnix(path, listenerx.WithUnixFileMode(0600), listenerx.WithUnixRemoveStale(false))
+	assert.Error(t, err)
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	conn, err := l.Dial()
+	require.NoError(t, err)
+	conn.Close()
+}
+
+func TestUnixOwner(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.sock")
+
+	l, err := listenerx.NewUnix(path, listenerx.WithUnixOwner(os.Getuid(), os.Getgid()), listenerx.WithUnixFileMode(0600))
+	require.NoError(t, err)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	// A second close must not unlink the path again
+	require.NoError(t, l.Close())
+	require.NoError(t, os.WriteFile(path, nil, 0600))
+	l.Close()
+
+	_, err = os.Stat(path)
+	assert.NoError(t, err)
+}
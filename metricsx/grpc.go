@@ -0,0 +1,61 @@
+package metricsx
+
+import (
+	"context"
+	"time"
+
+	"github.com/oligarch316/go-netx"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+type grpcMetrics struct {
+	requests *CounterVec
+	duration *HistogramVec
+	svc      string
+}
+
+func newGRPCMetrics(reg *Registry, id netx.ServiceID) grpcMetrics {
+	return grpcMetrics{
+		requests: reg.Counter("netx_grpc_requests_total", "gRPC requests by method and status code.", "service", "method", "code"),
+		duration: reg.Histogram("netx_grpc_request_duration_seconds", "gRPC request latency by method.", nil, "service", "method"),
+		svc:      id.String(),
+	}
+}
+
+func (gm grpcMetrics) observe(method string, start time.Time, err error) {
+	gm.duration.With(gm.svc, method).Observe(time.Since(start).Seconds())
+	gm.requests.With(gm.svc, method, status.Code(err).String()).Inc()
+}
+
+// UnaryServerInterceptor TODO.
+func UnaryServerInterceptor(reg *Registry, id netx.ServiceID) grpc.UnaryServerInterceptor {
+	gm := newGRPCMetrics(reg, id)
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		res, err := handler(ctx, req)
+		gm.observe(info.FullMethod, start, err)
+		return res, err
+	}
+}
+
+// StreamServerInterceptor TODO.
+func StreamServerInterceptor(reg *Registry, id netx.ServiceID) grpc.StreamServerInterceptor {
+	gm := newGRPCMetrics(reg, id)
+
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		gm.observe(info.FullMethod, start, err)
+		return err
+	}
+}
+
+// GRPCServerOptions TODO.
+func GRPCServerOptions(reg *Registry, id netx.ServiceID) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(UnaryServerInterceptor(reg, id)),
+		grpc.ChainStreamInterceptor(StreamServerInterceptor(reg, id)),
+	}
+}
@@ -0,0 +1,49 @@
+package metricsx
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/oligarch316/go-netx"
+	"github.com/oligarch316/go-netx/internal/httpwriter"
+)
+
+// HTTPMiddleware TODO.
+func HTTPMiddleware(reg *Registry, id netx.ServiceID, next http.Handler) http.Handler {
+	var (
+		requests = reg.Counter("netx_http_requests_total", "HTTP requests by method and status code.", "service", "method", "code")
+		duration = reg.Histogram("netx_http_request_duration_seconds", "HTTP request latency by method.", nil, "service", "method")
+
+		svc = id.String()
+	)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var (
+			start = time.Now()
+			rw    = httpwriter.New(w)
+		)
+
+		next.ServeHTTP(rw, r)
+
+		status := rw.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		duration.With(svc, r.Method).Observe(time.Since(start).Seconds())
+		requests.With(svc, r.Method, strconv.Itoa(status)).Inc()
+	})
+}
+
+// HTTPServerOption wraps the server handler with HTTPMiddleware, and should
+// be applied after any option setting the handler.
+func HTTPServerOption(reg *Registry, id netx.ServiceID) func(*http.Server) {
+	return func(s *http.Server) {
+		next := s.Handler
+		if next == nil {
+			next = http.DefaultServeMux
+		}
+		s.Handler = HTTPMiddleware(reg, id, next)
+	}
+}
@@ -0,0 +1,136 @@
+package metricsx_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/oligarch316/go-netx/listenerx"
+	"github.com/oligarch316/go-netx/metricsx"
+	"github.com/oligarch316/go-netx/serverx"
+	"github.com/oligarch316/go-netx/servicex/httpx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistryExposition(t *testing.T) {
+	reg := metricsx.NewRegistry()
+
+	counter := reg.Counter("test_requests_total", "Requests.\nBy path.", "path")
+	counter.With("/b").Add(2)
+	counter.With(`/a"q`).Inc()
+
+	reg.Gauge("test_temperature", "Temperature.").With().Set(-1.5)
+
+	hist := reg.Histogram("test_latency_seconds", "Latency.", []float64{1, 0.5})
+	hist.With().Observe(0.25)
+	hist.With().Observe(0.75)
+	hist.With().Observe(3)
+
+	reg.GaugeFunc("test_func", "Func.", func() []metricsx.Sample {
+		return []metricsx.Sample{{LabelValues: []string{"x"}, Value: 7}}
+	}, "name")
+
+	expected := `# HELP test_func Func.
+# TYPE test_func gauge
+test_func{name="x"} 7
+# HELP test_latency_seconds Latency.
+# TYPE test_latency_seconds histogram
+test_latency_seconds_bucket{le="0.5"} 1
+test_latency_seconds_bucket{le="1"} 2
+test_latency_seconds_bucket{le="+Inf"} 3
+test_latency_seconds_sum 4
+test_latency_seconds_count 3
+# HELP test_requests_total Requests.\nBy path.
+# TYPE test_requests_total counter
+test_requests_total{path="/a\"q"} 1
+test_requests_total{path="/b"} 2
+# HELP test_temperature Temperature.
+# TYPE test_temperature gauge
+test_temperature -1.5
+`
+
+	var buf bytes.Buffer
+	reg.WriteText(&buf)
+	assert.Equal(t, expected, buf.String())
+
+	rec := httptest.NewRecorder()
+	reg.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, expected, rec.Body.String())
+	assert.Contains(t, rec.Header().Get("Content-Type"), "version=0.0.4")
+}
+
+func TestRegistryConflict(t *testing.T) {
+	reg := metricsx.NewRegistry()
+	reg.Counter("test_total", "Test.", "a")
+
+	assert.NotPanics(t, func() { reg.Counter("test_total", "Test.", "a") })
+	assert.Panics(t, func() { reg.Gauge("test_total", "Test.", "a") })
+	assert.Panics(t, func() { reg.Counter("test_total", "Test.", "b") })
+	assert.Panics(t, func() { reg.Counter("test_total", "Test.", "a").With() })
+}
+
+func TestServerMetrics(t *testing.T) {
+	reg := metricsx.NewRegistry()
+
+	svr, err := serverx.NewServer(
+		httpx.WithListeners(listenerx.NewInternal(0)),
+		serverx.WithListenerOpts(httpx.ID, metricsx.ListenerOption(reg, httpx.ID)),
+		metricsx.ServerOption(reg),
+	)
+	require.NoError(t, err)
+
+	metricsx.ObserveServer(reg, svr)
+
+	svc := httpx.NewService(httpx.WithHTTPServerOptions(
+		func(s *http.Server) {
+			s.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/missing" {
+					http.NotFound(w, r)
+					return
+				}
+				io.WriteString(w, "ok")
+			})
+		},
+		metricsx.HTTPServerOption(reg, httpx.ID),
+	))
+
+	errs, err := svr.Serve(svc)
+	require.NoError(t, err)
+
+	// One connection per request keeps dial and accept counts deterministic
+	transport, err := httpx.LoadTransport(svr, httpx.WithHTTPTransportOptions(func(t *http.Transport) { t.DisableKeepAlives = true }))
+	require.NoError(t, err)
+
+	client := &http.Client{Transport: transport}
+	for _, path := range []string{"/", "/", "/missing"} {
+		resp, err := client.Get("localapp://" + path)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	transport.CloseIdleConnections()
+
+	svr.Close(context.Background())
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+
+	var buf bytes.Buffer
+	reg.WriteText(&buf)
+	text := buf.String()
+
+	for _, expected := range []string{
+		`netx_http_requests_total{service="httpx",method="GET",code="200"} 2`,
+		`netx_http_requests_total{service="httpx",method="GET",code="404"} 1`,
+		`netx_http_request_duration_seconds_count{service="httpx",method="GET"} 3`,
+		`netx_dials_total{service="httpx",strategy="multi.DialStrategyFirstOnly",result="success"} 3`,
+		`netx_dial_attempts_total{service="httpx",strategy="multi.DialStrategyFirstOnly",result="success"} 3`,
+		`netx_accepted_connections_total{service="httpx"} 3`,
+		`netx_runner_duration_seconds_count{service="httpx",runner="service",action="Close"} 1`,
+	} {
+		assert.Contains(t, text, expected)
+	}
+}
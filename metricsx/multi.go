@@ -0,0 +1,115 @@
+package metricsx
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/oligarch316/go-netx"
+	"github.com/oligarch316/go-netx/listenerx/multi"
+)
+
+const (
+	resultSuccess = "success"
+	resultFailure = "failure"
+)
+
+func result(err error) string {
+	if err != nil {
+		return resultFailure
+	}
+	return resultSuccess
+}
+
+func funcName(f interface{}) string {
+	v := reflect.ValueOf(f)
+	if !v.IsValid() || v.IsNil() {
+		return ""
+	}
+
+	name := runtime.FuncForPC(v.Pointer()).Name()
+	if idx := strings.LastIndex(name, "/"); idx >= 0 {
+		name = name[idx+1:]
+	}
+	return name
+}
+
+func eventType(re multi.RunnerEvent) string {
+	switch re.(type) {
+	case multi.RunnerEventTemporaryAcceptError:
+		return "temporary_accept_error"
+	case multi.RunnerEventListenerCloseError:
+		return "listener_close_error"
+	case multi.RunnerEventUnprocessedConnectionCloseError:
+		return "unprocessed_connection_close_error"
+	case multi.RunnerEventCloseContextExpiredError:
+		return "close_context_expired"
+	case multi.RunnerEventAcceptRateLimited:
+		return "accept_rate_limited"
+	case multi.RunnerEventConnTimeout:
+		return "conn_timeout"
+	default:
+		return "unknown"
+	}
+}
+
+// RunnerEventHandler TODO.
+func RunnerEventHandler(reg *Registry, id netx.ServiceID) multi.RunnerEventHandler {
+	events := reg.Counter("netx_runner_events_total", "Multi listener runner events by type.", "service", "type")
+
+	return func(re multi.RunnerEvent) {
+		events.With(id.String(), eventType(re)).Inc()
+	}
+}
+
+// DialStrategy wraps strategy to record dials and the individual dial
+// attempts it makes for the listener of id.
+func DialStrategy(reg *Registry, id netx.ServiceID, strategy multi.DialStrategy) multi.DialStrategy {
+	var (
+		dials    = reg.Counter("netx_dials_total", "Multi dialer dials by strategy and result.", "service", "strategy", "result")
+		attempts = reg.Counter("netx_dial_attempts_total", "Multi dialer dial attempts by strategy and result.", "service", "strategy", "result")
+		duration = reg.Histogram("netx_dial_duration_seconds", "Multi dialer dial latency by strategy.", nil, "service", "strategy")
+
+		svc  = id.String()
+		name = funcName(strategy)
+	)
+
+	return func(ctx context.Context, addrs []multi.SetAddr, dialHash multi.DialHashFunc) (net.Conn, error) {
+		countedHash := func(ctx context.Context, hash multi.SetHash) (net.Conn, error) {
+			conn, err := dialHash(ctx, hash)
+			attempts.With(svc, name, result(err)).Inc()
+			return conn, err
+		}
+
+		start := time.Now()
+		conn, err := strategy(ctx, addrs, countedHash)
+
+		duration.With(svc, name).Observe(time.Since(start).Seconds())
+		dials.With(svc, name, result(err)).Inc()
+		return conn, err
+	}
+}
+
+// ListenerOption records runner events and dial attempts for the listener of
+// id. It chains onto the event handler and dial strategy already configured,
+// so it should be applied after any options setting either.
+func ListenerOption(reg *Registry, id netx.ServiceID) multi.ListenerOption {
+	handler := RunnerEventHandler(reg, id)
+
+	return func(p *multi.ListenerParams) {
+		prev := p.Runner.EventHandler
+		p.Runner.EventHandler = func(re multi.RunnerEvent) {
+			if prev != nil {
+				prev(re)
+			}
+			handler(re)
+		}
+
+		if p.Dialer.Strategy != nil {
+			p.Dialer.Strategy = DialStrategy(reg, id, p.Dialer.Strategy)
+		}
+	}
+}
@@ -0,0 +1,322 @@
+package metricsx
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultBuckets TODO.
+var DefaultBuckets = []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+type metricKind string
+
+const (
+	kindCounter   metricKind = "counter"
+	kindGauge     metricKind = "gauge"
+	kindHistogram metricKind = "histogram"
+)
+
+// Sample TODO.
+type Sample struct {
+	LabelValues []string
+	Value       float64
+}
+
+type family struct {
+	name, help string
+	kind       metricKind
+	labelNames []string
+	buckets    []float64
+
+	mu      sync.Mutex
+	series  map[string]*series
+	collect func() []Sample
+}
+
+type series struct {
+	// Counters and gauges use bits alone, histograms use every field. Accessed
+	// atomically, first for 64-bit alignment on 32-bit platforms
+	bits    uint64
+	count   uint64
+	sumBits uint64
+	counts  []uint64
+
+	labelValues []string
+}
+
+func atomicAddFloat(addr *uint64, delta float64) {
+	for {
+		old := atomic.LoadUint64(addr)
+		val := math.Float64bits(math.Float64frombits(old) + delta)
+		if atomic.CompareAndSwapUint64(addr, old, val) {
+			return
+		}
+	}
+}
+
+func atomicLoadFloat(addr *uint64) float64 { return math.Float64frombits(atomic.LoadUint64(addr)) }
+
+func (f *family) with(labelValues []string) *series {
+	if len(labelValues) != len(f.labelNames) {
+		panic(fmt.Sprintf("metricsx: %s: expected %d label values, got %d", f.name, len(f.labelNames), len(labelValues)))
+	}
+
+	key := strings.Join(labelValues, "\xff")
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	res, ok := f.series[key]
+	if !ok {
+		res = &series{labelValues: append([]string(nil), labelValues...)}
+		if f.kind == kindHistogram {
+			res.counts = make([]uint64, len(f.buckets))
+		}
+		f.series[key] = res
+	}
+	return res
+}
+
+// Registry TODO.
+type Registry struct {
+	mu       sync.Mutex
+	families map[string]*family
+}
+
+// NewRegistry TODO.
+func NewRegistry() *Registry {
+	return &Registry{families: make(map[string]*family)}
+}
+
+func (r *Registry) register(name, help string, kind metricKind, buckets []float64, labelNames []string) *family {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.families[name]; ok {
+		if existing.kind != kind || strings.Join(existing.labelNames, ",") != strings.Join(labelNames, ",") {
+			panic(fmt.Sprintf("metricsx: %s: conflicting registration", name))
+		}
+		return existing
+	}
+
+	res := &family{
+		name:       name,
+		help:       help,
+		kind:       kind,
+		labelNames: append([]string(nil), labelNames...),
+		buckets:    append([]float64(nil), buckets...),
+		series:     make(map[string]*series),
+	}
+
+	sort.Float64s(res.buckets)
+	r.families[name] = res
+	return res
+}
+
+// Counter TODO.
+func (r *Registry) Counter(name, help string, labelNames ...string) *CounterVec {
+	return &CounterVec{family: r.register(name, help, kindCounter, nil, labelNames)}
+}
+
+// Gauge TODO.
+func (r *Registry) Gauge(name, help string, labelNames ...string) *GaugeVec {
+	return &GaugeVec{family: r.register(name, help, kindGauge, nil, labelNames)}
+}
+
+// Histogram TODO.
+func (r *Registry) Histogram(name, help string, buckets []float64, labelNames ...string) *HistogramVec {
+	if buckets == nil {
+		buckets = DefaultBuckets
+	}
+	return &HistogramVec{family: r.register(name, help, kindHistogram, buckets, labelNames)}
+}
+
+// CounterFunc registers a counter whose samples are produced by f at scrape
+// time, for values already tracked elsewhere.
+func (r *Registry) CounterFunc(name, help string, f func() []Sample, labelNames ...string) {
+	r.register(name, help, kindCounter, nil, labelNames).collect = f
+}
+
+// GaugeFunc TODO.
+func (r *Registry) GaugeFunc(name, help string, f func() []Sample, labelNames ...string) {
+	r.register(name, help, kindGauge, nil, labelNames).collect = f
+}
+
+// ----- Vectors
+
+// CounterVec TODO.
+type CounterVec struct{ family *family }
+
+// With TODO.
+func (cv *CounterVec) With(labelValues ...string) *Counter {
+	return &Counter{series: cv.family.with(labelValues)}
+}
+
+// Counter TODO.
+type Counter struct{ series *series }
+
+// Inc TODO.
+func (c *Counter) Inc() { c.Add(1) }
+
+// Add TODO.
+func (c *Counter) Add(delta float64) {
+	if delta > 0 {
+		atomicAddFloat(&c.series.bits, delta)
+	}
+}
+
+// GaugeVec TODO.
+type GaugeVec struct{ family *family }
+
+// With TODO.
+func (gv *GaugeVec) With(labelValues ...string) *Gauge {
+	return &Gauge{series: gv.family.with(labelValues)}
+}
+
+// Gauge TODO.
+type Gauge struct{ series *series }
+
+// Set TODO.
+func (g *Gauge) Set(val float64) { atomic.StoreUint64(&g.series.bits, math.Float64bits(val)) }
+
+// Add TODO.
+func (g *Gauge) Add(delta float64) { atomicAddFloat(&g.series.bits, delta) }
+
+// HistogramVec TODO.
+type HistogramVec struct{ family *family }
+
+// With TODO.
+func (hv *HistogramVec) With(labelValues ...string) *Histogram {
+	return &Histogram{series: hv.family.with(labelValues), buckets: hv.family.buckets}
+}
+
+// Histogram TODO.
+type Histogram struct {
+	series  *series
+	buckets []float64
+}
+
+// Observe TODO.
+func (h *Histogram) Observe(val float64) {
+	if idx := sort.SearchFloat64s(h.buckets, val); idx < len(h.buckets) {
+		atomic.AddUint64(&h.series.counts[idx], 1)
+	}
+	atomic.AddUint64(&h.series.count, 1)
+	atomicAddFloat(&h.series.sumBits, val)
+}
+
+// ----- Exposition
+
+func formatFloat(val float64) string {
+	switch {
+	case math.IsInf(val, 1):
+		return "+Inf"
+	case math.IsInf(val, -1):
+		return "-Inf"
+	case math.IsNaN(val):
+		return "NaN"
+	default:
+		return strconv.FormatFloat(val, 'g', -1, 64)
+	}
+}
+
+var (
+	labelReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	helpReplacer  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+)
+
+func formatLabels(names, values []string, extraName, extraValue string) string {
+	if len(names) < 1 && extraName == "" {
+		return ""
+	}
+
+	pairs := make([]string, 0, len(names)+1)
+	for i, name := range names {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, name, labelReplacer.Replace(values[i])))
+	}
+
+	if extraName != "" {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, extraName, extraValue))
+	}
+
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func (f *family) samples() []*series {
+	if f.collect != nil {
+		var res []*series
+		for _, sample := range f.collect() {
+			if len(sample.LabelValues) == len(f.labelNames) {
+				res = append(res, &series{labelValues: sample.LabelValues, bits: math.Float64bits(sample.Value)})
+			}
+		}
+		return res
+	}
+
+	f.mu.Lock()
+	res := make([]*series, 0, len(f.series))
+	for _, item := range f.series {
+		res = append(res, item)
+	}
+	f.mu.Unlock()
+
+	return res
+}
+
+func (f *family) write(w io.Writer) {
+	items := f.samples()
+	sort.Slice(items, func(i, j int) bool {
+		return strings.Join(items[i].labelValues, "\xff") < strings.Join(items[j].labelValues, "\xff")
+	})
+
+	fmt.Fprintf(w, "# HELP %s %s\n", f.name, helpReplacer.Replace(f.help))
+	fmt.Fprintf(w, "# TYPE %s %s\n", f.name, f.kind)
+
+	for _, item := range items {
+		if f.kind != kindHistogram {
+			fmt.Fprintf(w, "%s%s %s\n", f.name, formatLabels(f.labelNames, item.labelValues, "", ""), formatFloat(atomicLoadFloat(&item.bits)))
+			continue
+		}
+
+		var cumulative uint64
+		for i, bound := range f.buckets {
+			cumulative += atomic.LoadUint64(&item.counts[i])
+			fmt.Fprintf(w, "%s_bucket%s %d\n", f.name, formatLabels(f.labelNames, item.labelValues, "le", formatFloat(bound)), cumulative)
+		}
+
+		count := atomic.LoadUint64(&item.count)
+		fmt.Fprintf(w, "%s_bucket%s %d\n", f.name, formatLabels(f.labelNames, item.labelValues, "le", "+Inf"), count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", f.name, formatLabels(f.labelNames, item.labelValues, "", ""), formatFloat(atomicLoadFloat(&item.sumBits)))
+		fmt.Fprintf(w, "%s_count%s %d\n", f.name, formatLabels(f.labelNames, item.labelValues, "", ""), count)
+	}
+}
+
+// WriteText writes every registered metric in the Prometheus text exposition
+// format, ordered by name.
+func (r *Registry) WriteText(w io.Writer) {
+	r.mu.Lock()
+	families := make([]*family, 0, len(r.families))
+	for _, f := range r.families {
+		families = append(families, f)
+	}
+	r.mu.Unlock()
+
+	sort.Slice(families, func(i, j int) bool { return families[i].name < families[j].name })
+
+	for _, f := range families {
+		f.write(w)
+	}
+}
+
+// ServeHTTP TODO.
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	r.WriteText(w)
+}
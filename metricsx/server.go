@@ -0,0 +1,75 @@
+package metricsx
+
+import (
+	"time"
+
+	"github.com/oligarch316/go-netx/listenerx/multi"
+	"github.com/oligarch316/go-netx/serverx"
+)
+
+// RunnerObserver TODO.
+func RunnerObserver(reg *Registry) serverx.RunnerObserver {
+	var (
+		duration = reg.Histogram("netx_runner_duration_seconds", "Server runner run and close durations.", nil, "service", "runner", "action")
+		errors   = reg.Counter("netx_runner_errors_total", "Server runner run and close errors.", "service", "runner", "action")
+	)
+
+	return func(info serverx.RunnerInfo, action serverx.RunnerAction, dur time.Duration, err error) {
+		labels := []string{info.ServiceID.String(), info.Name, string(action)}
+
+		duration.With(labels...).Observe(dur.Seconds())
+		if err != nil {
+			errors.With(labels...).Inc()
+		}
+	}
+}
+
+// ServerOption TODO.
+func ServerOption(reg *Registry) serverx.Option {
+	return serverx.WithRunnerObserver(RunnerObserver(reg))
+}
+
+// ObserveServer registers metrics read from the connection stats of every
+// listener in svr at scrape time. Only accepted connections are reported
+// unless connection tracking is enabled on the listeners.
+func ObserveServer(reg *Registry, svr *serverx.Server) {
+	collect := func(value func(multi.ConnStats) int64) func() []Sample {
+		return func() []Sample {
+			var res []Sample
+
+			for _, id := range svr.ServiceIDs() {
+				ml, err := svr.Listener(id)
+				if err != nil {
+					continue
+				}
+
+				res = append(res, Sample{
+					LabelValues: []string{id.String()},
+					Value:       float64(value(ml.Stats())),
+				})
+			}
+
+			return res
+		}
+	}
+
+	reg.CounterFunc(
+		"netx_accepted_connections_total", "Connections accepted by service listeners.",
+		collect(func(cs multi.ConnStats) int64 { return cs.Accepted }), "service",
+	)
+
+	reg.GaugeFunc(
+		"netx_active_connections", "Tracked connections currently open on service listeners.",
+		collect(func(cs multi.ConnStats) int64 { return cs.Active }), "service",
+	)
+
+	reg.CounterFunc(
+		"netx_connection_read_bytes_total", "Bytes read from tracked connections.",
+		collect(func(cs multi.ConnStats) int64 { return cs.BytesRead }), "service",
+	)
+
+	reg.CounterFunc(
+		"netx_connection_written_bytes_total", "Bytes written to tracked connections.",
+		collect(func(cs multi.ConnStats) int64 { return cs.BytesWritten }), "service",
+	)
+}
@@ -18,12 +18,29 @@ type Listener interface {
 	net.Listener
 }
 
+// PacketListener TODO.
+type PacketListener interface {
+	Dialer
+	net.PacketConn
+}
+
 // ServiceID TODO.
 type ServiceID fmt.Stringer
 
+// BaseService TODO.
+type BaseService interface {
+	ID() ServiceID
+	Close(context.Context) error
+}
+
 // Service TODO.
 type Service interface {
-	ID() ServiceID
+	BaseService
 	Serve(net.Listener) error
-	Close(context.Context) error
+}
+
+// PacketService TODO.
+type PacketService interface {
+	BaseService
+	ServePacket(net.PacketConn) error
 }
@@ -0,0 +1,87 @@
+package registryx
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"github.com/oligarch316/go-netx"
+	"github.com/oligarch316/go-netx/addressx"
+)
+
+var errUnknownDialFailure = errors.New("registryx: unknown dial failure")
+
+// DialerOption TODO.
+type DialerOption func(*DialerParams)
+
+// DialerParams TODO.
+type DialerParams struct {
+	AddressOrdering addressx.Ordering
+}
+
+func defaultDialerParams() DialerParams {
+	return DialerParams{
+		AddressOrdering: addressx.Ordering{
+			addressx.ByPriorityNetwork("unix", "tcp"),
+		},
+	}
+}
+
+// Dialer TODO.
+type Dialer struct {
+	params DialerParams
+	file   *File
+	id     netx.ServiceID
+	dialer net.Dialer
+}
+
+// NewDialer TODO.
+func NewDialer(f *File, id netx.ServiceID, opts ...DialerOption) *Dialer {
+	params := defaultDialerParams()
+	for _, opt := range opts {
+		opt(&params)
+	}
+	return &Dialer{params: params, file: f, id: id}
+}
+
+// Resolve TODO.
+func (d *Dialer) Resolve() ([]Entry, error) {
+	res, err := d.file.Lookup(d.id.String())
+	if err != nil {
+		return nil, err
+	}
+
+	SortEntries(res, d.params.AddressOrdering)
+	return res, nil
+}
+
+// Dial TODO.
+func (d *Dialer) Dial() (net.Conn, error) {
+	return d.DialContext(context.Background())
+}
+
+// DialContext TODO.
+func (d *Dialer) DialContext(ctx context.Context) (net.Conn, error) {
+	entries, err := d.Resolve()
+	if err != nil {
+		return nil, err
+	}
+
+	var firstErr error
+
+	for _, entry := range entries {
+		res, err := d.dialer.DialContext(ctx, entry.Network, entry.Address)
+		switch {
+		case err == nil:
+			return res, nil
+		case firstErr == nil:
+			firstErr = err
+		}
+	}
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	return nil, errUnknownDialFailure
+}
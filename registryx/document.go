@@ -0,0 +1,86 @@
+package registryx
+
+import (
+	"net"
+	"sort"
+	"time"
+
+	"github.com/oligarch316/go-netx/addressx"
+)
+
+// Entry TODO.
+type Entry struct {
+	Service string `json:"service"`
+	Network string `json:"network"`
+	Address string `json:"address"`
+}
+
+// Addr TODO.
+func (e Entry) Addr() net.Addr { return entryAddr{network: e.Network, address: e.Address} }
+
+type entryAddr struct{ network, address string }
+
+func (ea entryAddr) Network() string { return ea.network }
+func (ea entryAddr) String() string  { return ea.address }
+
+// SortEntries TODO.
+func SortEntries(entries []Entry, ordering addressx.Ordering) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return ordering.Less(entries[i].Addr(), entries[j].Addr())
+	})
+}
+
+// Owner TODO.
+type Owner struct {
+	PID     int       `json:"pid"`
+	Updated time.Time `json:"updated"`
+	Entries []Entry   `json:"entries"`
+}
+
+// Alive reports whether the publishing process is still running. The registry
+// is host local, so the PID is checked against this host's process table. An
+// owner without a PID is assumed alive.
+func (o Owner) Alive() bool { return o.PID <= 0 || processAlive(o.PID) }
+
+// Document TODO.
+type Document struct {
+	Owners map[string]Owner `json:"owners"`
+}
+
+// Prune removes owners whose publishing process has exited without
+// unpublishing.
+func (d *Document) Prune() {
+	for name, owner := range d.Owners {
+		if !owner.Alive() {
+			delete(d.Owners, name)
+		}
+	}
+}
+
+// Lookup TODO.
+//
+// Entries of owners that are no longer alive are skipped.
+func (d Document) Lookup(service string) []Entry {
+	names := make([]string, 0, len(d.Owners))
+	for name := range d.Owners {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var res []Entry
+	for _, name := range names {
+		owner := d.Owners[name]
+		if !owner.Alive() {
+			continue
+		}
+
+		for _, entry := range owner.Entries {
+			if entry.Service == service {
+				res = append(res, entry)
+			}
+		}
+	}
+
+	return res
+}
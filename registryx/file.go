@@ -0,0 +1,239 @@
+package registryx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+var errNoSuchService = errors.New("registryx: no such service")
+
+// FileOption TODO.
+type FileOption func(*FileParams)
+
+// FileParams TODO.
+type FileParams struct {
+	FileMode     os.FileMode
+	PollInterval time.Duration
+}
+
+func defaultFileParams() FileParams {
+	return FileParams{
+		FileMode:     0644,
+		PollInterval: 1 * time.Second,
+	}
+}
+
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+func newFileStamp(info fs.FileInfo) fileStamp {
+	if info == nil {
+		return fileStamp{}
+	}
+	return fileStamp{modTime: info.ModTime(), size: info.Size()}
+}
+
+// File TODO.
+type File struct {
+	params FileParams
+	path   string
+
+	mu         sync.Mutex
+	cacheStamp fileStamp
+	cacheDoc   Document
+}
+
+// NewFile TODO.
+func NewFile(path string, opts ...FileOption) *File {
+	params := defaultFileParams()
+	for _, opt := range opts {
+		opt(&params)
+	}
+	return &File{params: params, path: path}
+}
+
+// Path TODO.
+func (f *File) Path() string { return f.path }
+
+func (f *File) stat() (fileStamp, error) {
+	info, err := os.Stat(f.path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return fileStamp{}, nil
+	case err != nil:
+		return fileStamp{}, fmt.Errorf("registryx: %w", err)
+	}
+	return newFileStamp(info), nil
+}
+
+func (f *File) read() (Document, error) {
+	var res Document
+
+	data, err := os.ReadFile(f.path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return res, nil
+	case err != nil:
+		return res, fmt.Errorf("registryx: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &res); err != nil {
+		return res, fmt.Errorf("registryx: invalid registry file '%s': %w", f.path, err)
+	}
+
+	return res, nil
+}
+
+func (f *File) write(doc Document) error {
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("registryx: %w", err)
+	}
+
+	// Write to a temporary file and rename so that readers, which do not take
+	// the lock, never observe a partially written document.
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("registryx: %w", err)
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("registryx: %w", err)
+	}
+
+	if err := tmp.Chmod(f.params.FileMode); err != nil {
+		tmp.Close()
+		return fmt.Errorf("registryx: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("registryx: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), f.path); err != nil {
+		return fmt.Errorf("registryx: %w", err)
+	}
+
+	return nil
+}
+
+func (f *File) update(modify func(*Document)) error {
+	unlock, err := lockFile(f.path + ".lock")
+	if err != nil {
+		return fmt.Errorf("registryx: %w", err)
+	}
+
+	defer unlock()
+
+	doc, err := f.read()
+	if err != nil {
+		return err
+	}
+
+	// Publishers that crashed never unpublish, drop them whenever the
+	// document is rewritten
+	doc.Prune()
+
+	modify(&doc)
+	return f.write(doc)
+}
+
+// Load TODO.
+func (f *File) Load() (Document, error) {
+	stamp, err := f.stat()
+	if err != nil {
+		return Document{}, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if stamp != (fileStamp{}) && stamp == f.cacheStamp {
+		return f.cacheDoc, nil
+	}
+
+	doc, err := f.read()
+	if err != nil {
+		return doc, err
+	}
+
+	f.cacheStamp, f.cacheDoc = stamp, doc
+	return doc, nil
+}
+
+// Lookup TODO.
+func (f *File) Lookup(service string) ([]Entry, error) {
+	doc, err := f.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	res := doc.Lookup(service)
+	if len(res) < 1 {
+		return nil, fmt.Errorf("%w: %s", errNoSuchService, service)
+	}
+
+	return res, nil
+}
+
+// Publish TODO.
+func (f *File) Publish(owner string, entries ...Entry) error {
+	return f.update(func(doc *Document) {
+		if doc.Owners == nil {
+			doc.Owners = make(map[string]Owner)
+		}
+
+		doc.Owners[owner] = Owner{
+			PID:     os.Getpid(),
+			Updated: time.Now().UTC(),
+			Entries: entries,
+		}
+	})
+}
+
+// Unpublish TODO.
+func (f *File) Unpublish(owner string) error {
+	return f.update(func(doc *Document) { delete(doc.Owners, owner) })
+}
+
+// Watch TODO.
+func (f *File) Watch(ctx context.Context, handler func(Document, error)) {
+	var (
+		ticker = time.NewTicker(f.params.PollInterval)
+		last   fileStamp
+		first  = true
+	)
+
+	defer ticker.Stop()
+
+	for {
+		stamp, err := f.stat()
+
+		switch {
+		case err != nil:
+			handler(Document{}, err)
+		case first || stamp != last:
+			doc, err := f.Load()
+			handler(doc, err)
+			last, first = stamp, false
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
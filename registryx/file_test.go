@@ -0,0 +1,183 @@
+package registryx_test
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/oligarch316/go-netx/listenerx"
+	"github.com/oligarch316/go-netx/registryx"
+	"github.com/oligarch316/go-netx/serverx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testID string
+
+func (ti testID) String() string { return string(ti) }
+
+func TestFilePublish(t *testing.T) {
+	var (
+		file = registryx.NewFile(filepath.Join(t.TempDir(), "registry.json"))
+
+		entryA1 = registryx.Entry{Service: "A", Network: "tcp", Address: "127.0.0.1:1"}
+		entryA2 = registryx.Entry{Service: "A", Network: "unix", Address: "/tmp/a.sock"}
+		entryB1 = registryx.Entry{Service: "B", Network: "tcp", Address: "127.0.0.1:2"}
+	)
+
+	require.NoError(t, file.Publish("owner1", entryA1, entryB1))
+	require.NoError(t, file.Publish("owner2", entryA2))
+
+	entries, err := file.Lookup("A")
+	require.NoError(t, err)
+	assert.Equal(t, []registryx.Entry{entryA1, entryA2}, entries)
+
+	require.NoError(t, file.Unpublish("owner1"))
+
+	entries, err = file.Lookup("A")
+	require.NoError(t, err)
+	assert.Equal(t, []registryx.Entry{entryA2}, entries)
+
+	_, err = file.Lookup("B")
+	assert.Error(t, err)
+}
+
+func exitedPID(t *testing.T) int {
+	t.Helper()
+
+	// Re-run the test binary without any tests for a short-lived process
+	cmd := exec.Command(os.Args[0], "-test.run=^$")
+	require.NoError(t, cmd.Run())
+	return cmd.ProcessState.Pid()
+}
+
+func TestDocumentPrune(t *testing.T) {
+	switch runtime.GOOS {
+	case "darwin", "dragonfly", "freebsd", "linux", "netbsd", "openbsd":
+	default:
+		t.Skip("process liveness only supported on unix")
+	}
+
+	var (
+		entryLive = registryx.Entry{Service: "A", Network: "tcp", Address: "127.0.0.1:1"}
+		entryDead = registryx.Entry{Service: "A", Network: "tcp", Address: "127.0.0.1:2"}
+		entryNone = registryx.Entry{Service: "A", Network: "tcp", Address: "127.0.0.1:3"}
+
+		doc = registryx.Document{
+			Owners: map[string]registryx.Owner{
+				"live": {PID: os.Getpid(), Entries: []registryx.Entry{entryLive}},
+				"dead": {PID: exitedPID(t), Entries: []registryx.Entry{entryDead}},
+				"none": {Entries: []registryx.Entry{entryNone}},
+			},
+		}
+	)
+
+	assert.Equal(t, []registryx.Entry{entryLive, entryNone}, doc.Lookup("A"))
+
+	doc.Prune()
+	assert.Contains(t, doc.Owners, "live")
+	assert.Contains(t, doc.Owners, "none")
+	assert.NotContains(t, doc.Owners, "dead")
+}
+
+type watchResult struct {
+	doc registryx.Document
+	err error
+}
+
+func TestFileWatch(t *testing.T) {
+	var (
+		file = registryx.NewFile(
+			filepath.Join(t.TempDir(), "registry.json"),
+			registryx.WithPollInterval(time.Millisecond),
+		)
+
+		entry      = registryx.Entry{Service: "A", Network: "tcp", Address: "127.0.0.1:1"}
+		resultChan = make(chan watchResult, 1)
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go file.Watch(ctx, func(doc registryx.Document, err error) {
+		resultChan <- watchResult{doc: doc, err: err}
+	})
+
+	result := <-resultChan
+	require.NoError(t, result.err)
+	assert.Empty(t, result.doc.Lookup("A"))
+
+	require.NoError(t, file.Publish("owner", entry))
+
+	result = <-resultChan
+	require.NoError(t, result.err)
+	assert.Equal(t, []registryx.Entry{entry}, result.doc.Lookup("A"))
+}
+
+func TestDialerPublishedServer(t *testing.T) {
+	var (
+		id   = testID("A")
+		file = registryx.NewFile(filepath.Join(t.TempDir(), "registry.json"))
+	)
+
+	l, err := listenerx.New("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+
+	svr, err := serverx.NewServer(
+		serverx.WithListeners(id, l, listenerx.NewInternal(256)),
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, registryx.PublishServer(file, "owner", svr))
+	defer svr.Close(context.Background())
+
+	dialer := registryx.NewDialer(file, id)
+
+	entries, err := dialer.Resolve()
+	require.NoError(t, err)
+	assert.Equal(t, []registryx.Entry{{Service: "A", Network: "tcp", Address: l.Addr().String()}}, entries)
+
+	acceptChan := make(chan error, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err == nil {
+			conn.Close()
+		}
+		acceptChan <- err
+	}()
+
+	conn, err := dialer.Dial()
+	require.NoError(t, err)
+	conn.Close()
+
+	assert.NoError(t, <-acceptChan)
+}
+
+func TestPublishServerClose(t *testing.T) {
+	var (
+		id   = testID("A")
+		file = registryx.NewFile(filepath.Join(t.TempDir(), "registry.json"))
+	)
+
+	l, err := listenerx.New("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+
+	svr, err := serverx.NewServer(serverx.WithListeners(id, l))
+	require.NoError(t, err)
+
+	require.NoError(t, registryx.PublishServer(file, "owner", svr))
+
+	_, err = file.Lookup(id.String())
+	require.NoError(t, err)
+
+	svr.Close(context.Background())
+
+	_, err = file.Lookup(id.String())
+	assert.Error(t, err)
+}
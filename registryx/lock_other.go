@@ -0,0 +1,8 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd
+
+package registryx
+
+// lockFile is a no-op on platforms without flock(2). Concurrent publishers on
+// such platforms may lose each other's updates.
+func lockFile(string) (unlock func(), err error) { return func() {}, nil }
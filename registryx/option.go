@@ -0,0 +1,27 @@
+package registryx
+
+import (
+	"os"
+	"time"
+
+	"github.com/oligarch316/go-netx/addressx"
+)
+
+// ----- File Options
+
+// WithFileMode TODO.
+func WithFileMode(mode os.FileMode) FileOption {
+	return func(p *FileParams) { p.FileMode = mode }
+}
+
+// WithPollInterval TODO.
+func WithPollInterval(interval time.Duration) FileOption {
+	return func(p *FileParams) { p.PollInterval = interval }
+}
+
+// ----- Dialer Options
+
+// WithDialerAddressOrdering TODO.
+func WithDialerAddressOrdering(ordering addressx.Ordering) DialerOption {
+	return func(p *DialerParams) { p.AddressOrdering = ordering }
+}
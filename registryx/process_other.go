@@ -0,0 +1,8 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd
+
+package registryx
+
+// processAlive assumes every process is alive on platforms without kill(2).
+// Entries of crashed publishers on such platforms remain until overwritten.
+func processAlive(int) bool { return true }
@@ -0,0 +1,16 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+// +build darwin dragonfly freebsd linux netbsd openbsd
+
+package registryx
+
+import (
+	"errors"
+	"syscall"
+)
+
+func processAlive(pid int) bool {
+	// Signal 0 performs the existence and permission checks without sending
+	// anything, EPERM still means the process exists
+	err := syscall.Kill(pid, 0)
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
@@ -0,0 +1,44 @@
+package registryx
+
+import (
+	"context"
+
+	"github.com/oligarch316/go-netx/listenerx"
+	"github.com/oligarch316/go-netx/serverx"
+)
+
+// PublishServer TODO.
+//
+// The entries are unpublished when svr is closed.
+func PublishServer(f *File, owner string, svr *serverx.Server) error {
+	var entries []Entry
+
+	for _, id := range svr.ServiceIDs() {
+		dialer, err := svr.Dialer(id)
+		if err != nil {
+			return err
+		}
+
+		for _, addr := range dialer.Resolve() {
+			// Internal listeners are only reachable from within this process
+			if addr.Network() == listenerx.InternalNetwork {
+				continue
+			}
+
+			entries = append(entries, Entry{
+				Service: id.String(),
+				Network: addr.Network(),
+				Address: addr.String(),
+			})
+		}
+	}
+
+	if err := f.Publish(owner, entries...); err != nil {
+		return err
+	}
+
+	// Best effort, entries left behind by a failed unpublish are pruned once
+	// this process exits
+	svr.OnClose(func(context.Context) { f.Unpublish(owner) })
+	return nil
+}
@@ -0,0 +1,464 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/oligarch316/go-netx"
+	"github.com/oligarch316/go-netx/addressx"
+	"github.com/oligarch316/go-netx/listenerx"
+	"github.com/oligarch316/go-netx/listenerx/multi"
+	"github.com/oligarch316/go-netx/listenerx/retry"
+	"github.com/oligarch316/go-netx/serverx"
+	"gopkg.in/yaml.v3"
+)
+
+// LoaderOption TODO.
+type LoaderOption func(*LoaderParams)
+
+// LoaderParams TODO.
+type LoaderParams struct {
+	ServiceIDs map[string]netx.ServiceID
+}
+
+// WithServiceIDs TODO.
+func WithServiceIDs(ids ...netx.ServiceID) LoaderOption {
+	return func(p *LoaderParams) {
+		for _, id := range ids {
+			p.ServiceIDs[id.String()] = id
+		}
+	}
+}
+
+// Loader TODO.
+type Loader struct {
+	params LoaderParams
+
+	mu        sync.Mutex
+	listeners []netx.Listener
+}
+
+// NewLoader TODO.
+func NewLoader(opts ...LoaderOption) *Loader {
+	params := LoaderParams{ServiceIDs: make(map[string]netx.ServiceID)}
+	for _, opt := range opts {
+		opt(&params)
+	}
+	return &Loader{params: params}
+}
+
+// Close closes every listener opened by the loader. The server built from
+// loaded options only closes the listeners of services it serves, so Close
+// releases the remainder should NewServer or Serve fail.
+func (l *Loader) Close() {
+	l.mu.Lock()
+	ls := l.listeners
+	l.listeners = nil
+	l.mu.Unlock()
+
+	// Listeners already closed by their runners report errors of no interest
+	for _, item := range ls {
+		item.Close()
+	}
+}
+
+// LoadFile TODO.
+func (l *Loader) LoadFile(path string) ([]serverx.Option, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("config: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return l.LoadYAML(data)
+	default:
+		return l.LoadJSON(data)
+	}
+}
+
+// LoadJSON TODO.
+func (l *Loader) LoadJSON(data []byte) ([]serverx.Option, error) {
+	var (
+		root interface{}
+		dec  = json.NewDecoder(bytes.NewReader(data))
+	)
+
+	dec.UseNumber()
+
+	if err := dec.Decode(&root); err != nil {
+		return nil, PathError{error: fmt.Errorf("invalid json: %w", err)}
+	}
+
+	return l.loadRoot(node{value: root})
+}
+
+// LoadYAML TODO.
+func (l *Loader) LoadYAML(data []byte) ([]serverx.Option, error) {
+	var root interface{}
+
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, PathError{error: fmt.Errorf("invalid yaml: %w", err)}
+	}
+
+	return l.loadRoot(node{value: root})
+}
+
+func (l *Loader) loadRoot(root node) ([]serverx.Option, error) {
+	res, ls, err := l.load(root)
+	if err != nil {
+		return nil, err
+	}
+
+	l.mu.Lock()
+	l.listeners = append(l.listeners, ls...)
+	l.mu.Unlock()
+
+	return res, nil
+}
+
+type serviceConfig struct {
+	id           netx.ServiceID
+	specs        []listenerx.Spec
+	specNodes    []node
+	listenerOpts []multi.ListenerOption
+	dependencies []netx.ServiceID
+}
+
+func (l *Loader) load(root node) ([]serverx.Option, []netx.Listener, error) {
+	fields, err := root.object("ignore", "services")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		res      []serverx.Option
+		services []serviceConfig
+	)
+
+	// Parse and validate the entire document before opening any listeners
+	for _, field := range fields {
+		switch field.key {
+		case "ignore":
+			opt, err := l.loadIgnore(field)
+			if err != nil {
+				return nil, nil, err
+			}
+			res = append(res, opt)
+		case "services":
+			if services, err = l.loadServices(field); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	var opened []netx.Listener
+
+	for _, svc := range services {
+		ls := make([]netx.Listener, len(svc.specs))
+
+		for i, spec := range svc.specs {
+			if ls[i], err = spec.Listen(); err != nil {
+				for _, item := range opened {
+					item.Close()
+				}
+				return nil, nil, svc.specNodes[i].wrap(err)
+			}
+
+			opened = append(opened, ls[i])
+		}
+
+		res = append(
+			res,
+			serverx.WithListeners(svc.id, ls...),
+			serverx.WithListenerOpts(svc.id, svc.listenerOpts...),
+			serverx.WithDependencies(svc.id, svc.dependencies...),
+		)
+	}
+
+	return res, opened, nil
+}
+
+func (l *Loader) lookupID(n node, name string) (netx.ServiceID, error) {
+	if id, ok := l.params.ServiceIDs[name]; ok {
+		return id, nil
+	}
+	return nil, n.errorf("unknown service id '%s'", name)
+}
+
+func (l *Loader) loadIgnore(n node) (serverx.Option, error) {
+	fields, err := n.object("duplicateServices", "missingDependencies", "missingListeners")
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		setters = make([]func(*serverx.IgnoreParams), len(fields))
+		val     bool
+	)
+
+	for i, field := range fields {
+		if val, err = field.bool(); err != nil {
+			return nil, err
+		}
+
+		switch v := val; field.key {
+		case "duplicateServices":
+			setters[i] = func(p *serverx.IgnoreParams) { p.DuplicateServices = v }
+		case "missingDependencies":
+			setters[i] = func(p *serverx.IgnoreParams) { p.MissingDependencies = v }
+		case "missingListeners":
+			setters[i] = func(p *serverx.IgnoreParams) { p.MissingListeners = v }
+		}
+	}
+
+	return func(p *serverx.Params) {
+		for _, set := range setters {
+			set(&p.Ignore)
+		}
+	}, nil
+}
+
+func (l *Loader) loadServices(n node) ([]serviceConfig, error) {
+	fields, err := n.object()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]serviceConfig, len(fields))
+	for i, field := range fields {
+		if res[i], err = l.loadService(field); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+func (l *Loader) loadService(n node) (serviceConfig, error) {
+	var res serviceConfig
+
+	id, err := l.lookupID(n, n.key)
+	if err != nil {
+		return res, err
+	}
+
+	res.id = id
+
+	fields, err := n.object("listeners", "dependencies", "dialStrategy", "dialRetryDelay", "addressOrdering", "retryDelay")
+	if err != nil {
+		return res, err
+	}
+
+	for _, field := range fields {
+		switch field.key {
+		case "listeners":
+			err = l.loadListeners(field, &res)
+		case "dependencies":
+			err = l.loadDependencies(field, &res)
+		case "dialStrategy":
+			var strategy multi.DialStrategy
+			if strategy, err = loadDialStrategy(field); err == nil {
+				res.listenerOpts = append(res.listenerOpts, multi.WithDialerStrategy(strategy))
+			}
+		case "dialRetryDelay":
+			var delayFunc retry.DelayFunc
+			if delayFunc, err = loadDelayFunc(field); err == nil {
+				res.listenerOpts = append(res.listenerOpts, multi.WithDialerRetryDelay(delayFunc))
+			}
+		case "addressOrdering":
+			var ordering addressx.Ordering
+			if ordering, err = loadOrdering(field); err == nil {
+				res.listenerOpts = append(res.listenerOpts, multi.WithDialerAddressOrdering(ordering))
+			}
+		case "retryDelay":
+			var delayFunc retry.DelayFunc
+			if delayFunc, err = loadDelayFunc(field); err == nil {
+				res.listenerOpts = append(res.listenerOpts, multi.WithRunnerRetryDelay(delayFunc))
+			}
+		}
+
+		if err != nil {
+			return res, err
+		}
+	}
+
+	return res, nil
+}
+
+func (l *Loader) loadListeners(n node, svc *serviceConfig) error {
+	items, err := n.array()
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		str, err := item.string()
+		if err != nil {
+			return err
+		}
+
+		spec, err := listenerx.ParseSpec(str)
+		if err != nil {
+			return item.wrap(err)
+		}
+
+		svc.specs = append(svc.specs, spec)
+		svc.specNodes = append(svc.specNodes, item)
+	}
+
+	return nil
+}
+
+func (l *Loader) loadDependencies(n node, svc *serviceConfig) error {
+	items, err := n.array()
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		name, err := item.string()
+		if err != nil {
+			return err
+		}
+
+		id, err := l.lookupID(item, name)
+		if err != nil {
+			return err
+		}
+
+		svc.dependencies = append(svc.dependencies, id)
+	}
+
+	return nil
+}
+
+func loadDialStrategy(n node) (multi.DialStrategy, error) {
+	name, err := n.string()
+	if err != nil {
+		return nil, err
+	}
+
+	switch name {
+	case "firstOnly":
+		return multi.DialStrategyFirstOnly, nil
+	case "iterative":
+		return multi.DialStrategyIterative, nil
+	default:
+		return nil, n.errorf("unknown dial strategy '%s'", name)
+	}
+}
+
+func loadOrdering(n node) (addressx.Ordering, error) {
+	items, err := n.array()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(addressx.Ordering, len(items))
+	for i, item := range items {
+		if res[i], err = loadComparer(item); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+func loadComparer(n node) (addressx.Comparer, error) {
+	if name, ok := n.value.(string); ok {
+		switch name {
+		case "lexNetwork":
+			return addressx.ByLexNetwork, nil
+		case "lexAddress":
+			return addressx.ByLexAddress, nil
+		default:
+			return nil, n.errorf("unknown comparer '%s'", name)
+		}
+	}
+
+	fields, err := n.object("priorityNetwork", "priorityAddress")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(fields) != 1 {
+		return nil, n.errorf("expected exactly one comparer, found %d", len(fields))
+	}
+
+	items, err := fields[0].strings()
+	if err != nil {
+		return nil, err
+	}
+
+	if fields[0].key == "priorityNetwork" {
+		return addressx.ByPriorityNetwork(items...), nil
+	}
+	return addressx.ByPriorityAddress(items...), nil
+}
+
+func loadDelayFunc(n node) (retry.DelayFunc, error) {
+	fields, err := n.object("constant", "multiplicative", "exponential")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(fields) != 1 {
+		return nil, n.errorf("expected exactly one delay function, found %d", len(fields))
+	}
+
+	field := fields[0]
+
+	if field.key == "constant" {
+		duration, err := field.duration()
+		if err != nil {
+			return nil, err
+		}
+		return retry.DelayFuncConstant(duration), nil
+	}
+
+	params, err := field.object("min", "max", "factor")
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		min, max time.Duration
+		factor   float64
+		found    = make(map[string]bool)
+	)
+
+	for _, p := range params {
+		switch p.key {
+		case "min":
+			min, err = p.duration()
+		case "max":
+			max, err = p.duration()
+		case "factor":
+			factor, err = p.float()
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		found[p.key] = true
+	}
+
+	for _, key := range []string{"min", "max", "factor"} {
+		if !found[key] {
+			return nil, field.errorf("missing field '%s'", key)
+		}
+	}
+
+	if field.key == "multiplicative" {
+		return retry.DelayFuncMultiplicative(min, max, factor), nil
+	}
+	return retry.DelayFuncExponential(min, max, factor), nil
+}
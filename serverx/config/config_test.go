@@ -0,0 +1,192 @@
+package config_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/oligarch316/go-netx/serverx"
+	"github.com/oligarch316/go-netx/serverx/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testID string
+
+func (ti testID) String() string { return string(ti) }
+
+var (
+	idA = testID("A")
+	idB = testID("B")
+)
+
+func TestLoad(t *testing.T) {
+	subtests := []struct {
+		name string
+		load func(*config.Loader) ([]serverx.Option, error)
+	}{
+		{
+			name: "json",
+			load: func(l *config.Loader) ([]serverx.Option, error) {
+				return l.LoadJSON([]byte(`{
+					"ignore": {"missingDependencies": true},
+					"services": {
+						"A": {
+							"listeners": ["internal?size=1024", "tcp://127.0.0.1:0"],
+							"dialStrategy": "iterative",
+							"addressOrdering": [{"priorityNetwork": ["tcp"]}, "lexAddress"],
+							"retryDelay": {"exponential": {"min": "5ms", "max": "1s", "factor": 2}},
+							"dependencies": ["B"]
+						},
+						"B": {"listeners": ["internal"]}
+					}
+				}`))
+			},
+		},
+		{
+			name: "yaml",
+			load: func(l *config.Loader) ([]serverx.Option, error) {
+				return l.LoadYAML([]byte(`
+ignore:
+  missingDependencies: true
+services:
+  A:
+    listeners: ["internal?size=1024", "tcp://127.0.0.1:0"]
+    dialStrategy: iterative
+    addressOrdering:
+      - priorityNetwork: [tcp]
+      - lexAddress
+    retryDelay:
+      exponential: {min: 5ms, max: 1s, factor: 2}
+    dependencies: [B]
+  B:
+    listeners: [internal]
+`))
+			},
+		},
+	}
+
+	for _, item := range subtests {
+		subtest := item
+
+		t.Run(subtest.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts, err := subtest.load(config.NewLoader(config.WithServiceIDs(idA, idB)))
+			require.NoError(t, err)
+
+			var params serverx.Params
+			params.Services = make(serverx.ServiceParams)
+			for _, opt := range opts {
+				opt(&params)
+			}
+
+			assert.Equal(t, serverx.IgnoreParams{MissingDependencies: true}, params.Ignore)
+
+			svr, err := serverx.NewServer(opts...)
+			require.NoError(t, err)
+			defer svr.Close(context.Background())
+
+			dialer, err := svr.Dialer(idA)
+			require.NoError(t, err)
+
+			addrs := dialer.Resolve()
+			require.Len(t, addrs, 2)
+			assert.Equal(t, "tcp", addrs[0].Network())
+			assert.Equal(t, "internal", addrs[1].Network())
+		})
+	}
+}
+
+func TestLoaderClose(t *testing.T) {
+	loader := config.NewLoader(config.WithServiceIDs(idA, idB))
+
+	opts, err := loader.LoadJSON([]byte(`{
+		"services": {
+			"A": {"listeners": ["tcp://127.0.0.1:0"], "dependencies": ["B"]},
+			"B": {"listeners": ["internal"], "dependencies": ["A"]}
+		}
+	}`))
+	require.NoError(t, err)
+
+	svr, err := serverx.NewServer(opts...)
+	require.Error(t, err)
+
+	dialer, err := svr.Dialer(idA)
+	require.NoError(t, err)
+
+	// Listeners stay open until released by the loader
+	conn, err := dialer.Dial()
+	require.NoError(t, err)
+	conn.Close()
+
+	loader.Close()
+
+	_, err = dialer.Dial()
+	assert.Error(t, err)
+}
+
+func TestLoadErrors(t *testing.T) {
+	subtests := []struct {
+		name, document, expectedPath string
+	}{
+		{
+			name:         "unknown top level field",
+			document:     `{"servers": {}}`,
+			expectedPath: "servers",
+		},
+		{
+			name:         "unknown service id",
+			document:     `{"services": {"C": {}}}`,
+			expectedPath: "services.C",
+		},
+		{
+			name:         "invalid listener spec",
+			document:     `{"services": {"A": {"listeners": ["internal", "udp://:53"]}}}`,
+			expectedPath: "services.A.listeners[1]",
+		},
+		{
+			name:         "unknown dependency",
+			document:     `{"services": {"A": {"dependencies": ["C"]}}}`,
+			expectedPath: "services.A.dependencies[0]",
+		},
+		{
+			name:         "invalid ignore flag",
+			document:     `{"ignore": {"missingListeners": "yes"}}`,
+			expectedPath: "ignore.missingListeners",
+		},
+		{
+			name:         "invalid delay duration",
+			document:     `{"services": {"A": {"retryDelay": {"constant": "soon"}}}}`,
+			expectedPath: "services.A.retryDelay.constant",
+		},
+		{
+			name:         "missing delay field",
+			document:     `{"services": {"A": {"retryDelay": {"exponential": {"min": "5ms", "factor": 2}}}}}`,
+			expectedPath: "services.A.retryDelay.exponential",
+		},
+		{
+			name:         "invalid delay factor",
+			document:     `{"services": {"A": {"retryDelay": {"multiplicative": {"min": "5ms", "max": "1s", "factor": "2x"}}}}}`,
+			expectedPath: "services.A.retryDelay.multiplicative.factor",
+		},
+		{
+			name:         "unknown comparer",
+			document:     `{"services": {"A": {"addressOrdering": ["lexPort"]}}}`,
+			expectedPath: "services.A.addressOrdering[0]",
+		},
+	}
+
+	for _, item := range subtests {
+		subtest := item
+
+		t.Run(subtest.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := config.NewLoader(config.WithServiceIDs(idA, idB)).LoadJSON([]byte(subtest.document))
+
+			var pathErr config.PathError
+			require.ErrorAs(t, err, &pathErr)
+			assert.Equal(t, subtest.expectedPath, pathErr.Path)
+		})
+	}
+}
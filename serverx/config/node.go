@@ -0,0 +1,172 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// PathError TODO.
+type PathError struct {
+	error
+	Path string
+}
+
+func (pe PathError) Unwrap() error { return pe.error }
+
+func (pe PathError) Error() string {
+	if pe.Path == "" {
+		return fmt.Sprintf("config: %s", pe.error)
+	}
+	return fmt.Sprintf("config: %s: %s", pe.Path, pe.error)
+}
+
+// node is a location within a generically decoded (JSON or YAML) document.
+type node struct {
+	path, key string
+	value     interface{}
+}
+
+func (n node) errorf(format string, a ...interface{}) error {
+	return PathError{error: fmt.Errorf(format, a...), Path: n.path}
+}
+
+func (n node) wrap(err error) error { return PathError{error: err, Path: n.path} }
+
+func (n node) field(key string) node {
+	if n.path == "" {
+		return node{path: key, key: key}
+	}
+	return node{path: n.path + "." + key, key: key}
+}
+
+func (n node) index(i int) node { return node{path: n.path + "[" + strconv.Itoa(i) + "]"} }
+
+func (n node) typeName() string {
+	switch n.value.(type) {
+	case nil:
+		return "null"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case json.Number, int, float64:
+		return "number"
+	default:
+		return fmt.Sprintf("%T", n.value)
+	}
+}
+
+func (n node) object(allowed ...string) ([]node, error) {
+	m, ok := n.value.(map[string]interface{})
+	if !ok {
+		return nil, n.errorf("expected object, found %s", n.typeName())
+	}
+
+	allowedSet := make(map[string]bool)
+	for _, key := range allowed {
+		allowedSet[key] = true
+	}
+
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		if len(allowed) > 0 && !allowedSet[key] {
+			return nil, n.field(key).errorf("unknown field")
+		}
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	res := make([]node, len(keys))
+	for i, key := range keys {
+		res[i] = n.field(key)
+		res[i].value = m[key]
+	}
+
+	return res, nil
+}
+
+func (n node) array() ([]node, error) {
+	items, ok := n.value.([]interface{})
+	if !ok {
+		return nil, n.errorf("expected array, found %s", n.typeName())
+	}
+
+	res := make([]node, len(items))
+	for i, item := range items {
+		res[i] = n.index(i)
+		res[i].value = item
+	}
+
+	return res, nil
+}
+
+func (n node) string() (string, error) {
+	res, ok := n.value.(string)
+	if !ok {
+		return "", n.errorf("expected string, found %s", n.typeName())
+	}
+	return res, nil
+}
+
+func (n node) strings() ([]string, error) {
+	items, err := n.array()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]string, len(items))
+	for i, item := range items {
+		if res[i], err = item.string(); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+func (n node) bool() (bool, error) {
+	res, ok := n.value.(bool)
+	if !ok {
+		return false, n.errorf("expected boolean, found %s", n.typeName())
+	}
+	return res, nil
+}
+
+func (n node) float() (float64, error) {
+	switch val := n.value.(type) {
+	case json.Number:
+		res, err := val.Float64()
+		if err != nil {
+			return 0, n.wrap(err)
+		}
+		return res, nil
+	case int:
+		return float64(val), nil
+	case float64:
+		return val, nil
+	default:
+		return 0, n.errorf("expected number, found %s", n.typeName())
+	}
+}
+
+func (n node) duration() (time.Duration, error) {
+	str, err := n.string()
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := time.ParseDuration(str)
+	if err != nil {
+		return 0, n.wrap(err)
+	}
+
+	return res, nil
+}
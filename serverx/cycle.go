@@ -15,8 +15,6 @@ func (cil cycleIDList) Less(i, j int) bool { return cil[i].String() < cil[j].Str
 
 type cycleError []netx.ServiceID
 
-func (ce cycleError) complete() bool { return len(ce) > 1 && ce[0] == ce[len(ce)-1] }
-
 func (ce cycleError) Error() string {
 	var (
 		n        = len(ce)
@@ -30,51 +28,214 @@ func (ce cycleError) Error() string {
 	return strings.Join(reversed, " → ")
 }
 
-func cycleCheck(depMap map[netx.ServiceID][]netx.ServiceID) error {
+type cycleErrors []cycleError
+
+func (ce cycleErrors) Error() string {
+	strs := make([]string, len(ce))
+	for i, item := range ce {
+		strs[i] = item.Error()
+	}
+
+	if len(ce) == 1 {
+		return "serverx: dependency cycle: " + strs[0]
+	}
+	return "serverx: dependency cycles: " + strings.Join(strs, ", ")
+}
+
+// cycleComponents assigns each node of depMap accepted by include, dependencies
+// included, the index of its strongly connected component (Tarjan) within the
+// subgraph of accepted nodes, counting from one. Nodes in a component of one
+// are omitted unless they depend on themselves, since no cycle passes through
+// them.
+func cycleComponents(depMap map[netx.ServiceID][]netx.ServiceID, include func(netx.ServiceID) bool) map[netx.ServiceID]int {
 	var (
-		visited = make(map[netx.ServiceID]bool)
-		recurse func(netx.ServiceID) cycleError
+		res     = make(map[netx.ServiceID]int)
+		index   = make(map[netx.ServiceID]int)
+		lowLink = make(map[netx.ServiceID]int)
+		onStack = make(map[netx.ServiceID]bool)
+		stack   []netx.ServiceID
+		nComp   = 1
+		visit   func(netx.ServiceID)
 	)
 
-	recurse = func(svcID netx.ServiceID) cycleError {
-		if complete, exists := visited[svcID]; exists {
-			if complete {
-				return nil
+	visit = func(svcID netx.ServiceID) {
+		index[svcID], lowLink[svcID] = len(index), len(index)
+		onStack[svcID] = true
+		stack = append(stack, svcID)
+
+		selfLoop := false
+
+		for _, depID := range depMap[svcID] {
+			if !include(depID) {
+				continue
+			}
+
+			if depID == svcID {
+				selfLoop = true
+			}
+
+			if _, visited := index[depID]; !visited {
+				visit(depID)
+				if lowLink[depID] < lowLink[svcID] {
+					lowLink[svcID] = lowLink[depID]
+				}
+			} else if onStack[depID] && index[depID] < lowLink[svcID] {
+				lowLink[svcID] = index[depID]
 			}
+		}
+
+		if lowLink[svcID] != index[svcID] {
+			return
+		}
+
+		// svcID is the root of a component, pop its members
+		i := len(stack) - 1
+		for stack[i] != svcID {
+			i--
+		}
+
+		members := stack[i:]
+		stack = stack[:i]
 
-			return cycleError{svcID}
+		for _, member := range members {
+			onStack[member] = false
 		}
 
-		visited[svcID] = false
-		defer func() { visited[svcID] = true }()
+		if len(members) > 1 || selfLoop {
+			for _, member := range members {
+				res[member] = nComp
+			}
+			nComp++
+		}
+	}
 
-		depIDs := cycleIDList(depMap[svcID])
-		sort.Stable(depIDs)
+	for svcID := range depMap {
+		if _, visited := index[svcID]; !visited && include(svcID) {
+			visit(svcID)
+		}
+	}
+
+	return res
+}
 
+// cycleCheck reports every elementary cycle in depMap. Each cycle is found
+// exactly once, starting (and ending) at its lexically smallest member.
+//
+// Cycles are enumerated by Johnson's algorithm: starts are taken in order,
+// each searched for within its strongly connected component of the nodes not
+// yet taken. Nodes from which no path currently leads back to the start stay
+// blocked until one does, so that the search takes O((n+e)(c+1)) time for n
+// nodes, e dependencies and c cycles, beyond the O(n+e) component computation
+// of each start.
+func cycleCheck(depMap map[netx.ServiceID][]netx.ServiceID) error {
+	nodeSet := make(map[netx.ServiceID]bool)
+	for svcID, depIDs := range depMap {
+		nodeSet[svcID] = true
 		for _, depID := range depIDs {
-			if err := recurse(depID); err != nil {
-				if err.complete() {
-					return err
+			nodeSet[depID] = true
+		}
+	}
+
+	nodes := make(cycleIDList, 0, len(nodeSet))
+	for svcID := range nodeSet {
+		nodes = append(nodes, svcID)
+	}
+
+	sort.Stable(nodes)
+
+	sortedDeps := make(map[netx.ServiceID]cycleIDList, len(depMap))
+	for svcID, depIDs := range depMap {
+		list := append(cycleIDList(nil), depIDs...)
+		sort.Stable(list)
+		sortedDeps[svcID] = list
+	}
+
+	var (
+		res      cycleErrors
+		start    netx.ServiceID
+		comps    map[netx.ServiceID]int
+		blocked  map[netx.ServiceID]bool
+		blockMap map[netx.ServiceID]map[netx.ServiceID]bool
+		path     []netx.ServiceID
+		unblock  func(netx.ServiceID)
+		circuit  func(netx.ServiceID) bool
+	)
+
+	unblock = func(svcID netx.ServiceID) {
+		blocked[svcID] = false
+
+		for depID := range blockMap[svcID] {
+			delete(blockMap[svcID], depID)
+			if blocked[depID] {
+				unblock(depID)
+			}
+		}
+	}
+
+	circuit = func(svcID netx.ServiceID) bool {
+		found := false
+
+		blocked[svcID] = true
+		path = append(path, svcID)
+
+		for _, depID := range sortedDeps[svcID] {
+			switch {
+			case comps[depID] != comps[start]:
+				// Outside of the component, no path leads back
+			case depID == start:
+				// Stored in reverse order, matching the dependency direction
+				cycle := cycleError{start}
+				for i := len(path) - 1; i >= 0; i-- {
+					cycle = append(cycle, path[i])
+				}
+				res = append(res, cycle)
+				found = true
+			case !blocked[depID]:
+				if circuit(depID) {
+					found = true
 				}
-				return append(err, svcID)
 			}
 		}
 
-		return nil
-	}
+		if found {
+			unblock(svcID)
+		} else {
+			// Stay blocked until a dependency finds its way back to start
+			for _, depID := range sortedDeps[svcID] {
+				if comps[depID] != comps[start] {
+					continue
+				}
 
-	rootIDs := make(cycleIDList, 0, len(depMap))
-	for rootID := range depMap {
-		rootIDs = append(rootIDs, rootID)
+				if blockMap[depID] == nil {
+					blockMap[depID] = make(map[netx.ServiceID]bool)
+				}
+				blockMap[depID][svcID] = true
+			}
+		}
+
+		path = path[:len(path)-1]
+		return found
 	}
 
-	sort.Stable(rootIDs)
+	for _, startID := range nodes {
+		start = startID
 
-	for _, rootID := range rootIDs {
-		if err := recurse(rootID); err != nil {
-			return err
+		// Cycles through nodes already taken have been reported
+		comps = cycleComponents(depMap, func(svcID netx.ServiceID) bool {
+			return svcID.String() >= start.String()
+		})
+
+		if _, ok := comps[start]; !ok {
+			continue
 		}
+
+		blocked = make(map[netx.ServiceID]bool)
+		blockMap = make(map[netx.ServiceID]map[netx.ServiceID]bool)
+		circuit(start)
 	}
 
+	if len(res) > 0 {
+		return res
+	}
 	return nil
 }
@@ -1,6 +1,7 @@
 package serverx
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/oligarch316/go-netx"
@@ -45,7 +46,7 @@ func TestServerDependencyCycles(t *testing.T) {
 			deps: depMap{
 				idA: {idA},
 			},
-			expected: cycleError{idA, idA},
+			expected: cycleErrors{{idA, idA}},
 		},
 		{
 			name: "multi element cycle",
@@ -55,7 +56,7 @@ func TestServerDependencyCycles(t *testing.T) {
 				idC: {idD},
 				idD: {idA},
 			},
-			expected: cycleError{idA, idD, idC, idB, idA},
+			expected: cycleErrors{{idA, idD, idC, idB, idA}},
 		},
 		{
 			name: "inner cycle",
@@ -65,7 +66,22 @@ func TestServerDependencyCycles(t *testing.T) {
 				idC: {idB},
 				idD: {idC},
 			},
-			expected: cycleError{idB, idC, idB},
+			expected: cycleErrors{{idB, idC, idB}},
+		},
+		{
+			name: "multiple cycles",
+			deps: depMap{
+				idA: {idB, idC},
+				idB: {idA, idC},
+				idC: {idA, idD},
+				idD: {idD},
+			},
+			expected: cycleErrors{
+				{idA, idB, idA},
+				{idA, idC, idB, idA},
+				{idA, idC, idA},
+				{idD, idD},
+			},
 		},
 	}
 
@@ -78,3 +94,84 @@ func TestServerDependencyCycles(t *testing.T) {
 		})
 	}
 }
+
+func TestServerDependencyCyclesError(t *testing.T) {
+	err := cycleCheck(depMap{
+		idA: {idB},
+		idB: {idA},
+		idC: {idC},
+	})
+
+	assert.EqualError(t, err, "serverx: dependency cycles: A → B → A, C → C")
+}
+
+func TestServerDependencyCyclesLayered(t *testing.T) {
+	// Every service depends on both services of the next layer, so that there
+	// are 2^n paths through the graph, with a single cycle at the bottom
+	const nLayers = 40
+
+	layer := func(i int) []netx.ServiceID {
+		return []netx.ServiceID{testID(fmt.Sprintf("%02d-a", i)), testID(fmt.Sprintf("%02d-b", i))}
+	}
+
+	deps := make(depMap)
+	for i := 0; i < nLayers; i++ {
+		for _, id := range layer(i) {
+			deps[id] = layer(i + 1)
+		}
+	}
+
+	bottom := layer(nLayers)
+	deps[bottom[0]] = []netx.ServiceID{bottom[1]}
+	deps[bottom[1]] = []netx.ServiceID{bottom[0]}
+
+	assert.Equal(t, cycleErrors{{bottom[0], bottom[1], bottom[0]}}, cycleCheck(deps))
+}
+
+func TestServerDependencyCyclesLadder(t *testing.T) {
+	// Within a single component, services of each layer depend on both
+	// services of the next, while the a services also depend on the one of
+	// the previous layer. There are 2^n paths down the ladder, but only
+	// 1 + n(n+1)/2 cycles, those through S and those up the a services.
+	const nLayers = 40
+
+	id := func(name string, i int) netx.ServiceID { return testID(fmt.Sprintf("%s%02d", name, i)) }
+
+	deps := depMap{testID("S"): {id("a", 0)}}
+	for i := 0; i <= nLayers; i++ {
+		for _, name := range []string{"a", "b"} {
+			if i < nLayers {
+				deps[id(name, i)] = []netx.ServiceID{id("a", i+1), id("b", i+1)}
+			}
+		}
+
+		if i > 0 {
+			deps[id("a", i)] = append(deps[id("a", i)], id("a", i-1))
+		}
+	}
+
+	deps[id("a", 0)] = append(deps[id("a", 0)], testID("S"))
+
+	var cycles cycleErrors
+	assert.ErrorAs(t, cycleCheck(deps), &cycles)
+	assert.Len(t, cycles, 1+nLayers*(nLayers+1)/2)
+}
+
+func TestServerDependencyCyclesComplete(t *testing.T) {
+	// Every service depends on every other, the elementary cycles number
+	// C(4,2)·1! + C(4,3)·2! + C(4,4)·3! = 20
+	ids := []netx.ServiceID{idA, idB, idC, idD}
+
+	deps := make(depMap)
+	for _, svcID := range ids {
+		for _, depID := range ids {
+			if depID != svcID {
+				deps[svcID] = append(deps[svcID], depID)
+			}
+		}
+	}
+
+	var cycles cycleErrors
+	assert.ErrorAs(t, cycleCheck(deps), &cycles)
+	assert.Len(t, cycles, 20)
+}
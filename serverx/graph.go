@@ -0,0 +1,186 @@
+package serverx
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/oligarch316/go-netx"
+)
+
+// GraphListener TODO.
+type GraphListener struct {
+	Network string `json:"network"`
+	Address string `json:"address"`
+	Packet  bool   `json:"packet,omitempty"`
+}
+
+func (gl GraphListener) String() string { return gl.Network + "://" + gl.Address }
+
+// GraphService TODO.
+type GraphService struct {
+	ID           string          `json:"id"`
+	Dependencies []string        `json:"dependencies"`
+	Listeners    []GraphListener `json:"listeners"`
+}
+
+// Graph TODO.
+type Graph struct {
+	Services []GraphService `json:"services"`
+
+	// StartupOrder lists services with dependencies ahead of their dependants,
+	// ShutdownOrder is the reverse.
+	StartupOrder  []string `json:"startupOrder"`
+	ShutdownOrder []string `json:"shutdownOrder"`
+}
+
+// Graph TODO.
+//
+// Services named only as dependencies are included, without dependencies or
+// listeners of their own.
+func (s *Server) Graph() Graph {
+	var (
+		res     Graph
+		nodeSet = make(map[netx.ServiceID]bool)
+	)
+
+	s.servicesMu.RLock()
+	defer s.servicesMu.RUnlock()
+
+	for id, deps := range s.services.dependencies {
+		nodeSet[id] = true
+		for _, dep := range deps {
+			nodeSet[dep] = true
+		}
+	}
+
+	nodes := make(cycleIDList, 0, len(nodeSet))
+	for id := range nodeSet {
+		nodes = append(nodes, id)
+	}
+
+	sort.Stable(nodes)
+
+	for _, id := range nodes {
+		svc := GraphService{
+			ID:           id.String(),
+			Dependencies: []string{},
+			Listeners:    []GraphListener{},
+		}
+
+		deps, _ := s.dependencies(id)
+		for _, dep := range deps {
+			svc.Dependencies = append(svc.Dependencies, dep.String())
+		}
+
+		if ml, ok := s.services.listeners[id]; ok {
+			for _, addr := range ml.Resolve() {
+				svc.Listeners = append(svc.Listeners, GraphListener{Network: addr.Network(), Address: addr.String()})
+			}
+		}
+
+		for _, pl := range s.services.packetListeners[id] {
+			addr := pl.LocalAddr()
+			svc.Listeners = append(svc.Listeners, GraphListener{Network: addr.Network(), Address: addr.String(), Packet: true})
+		}
+
+		res.Services = append(res.Services, svc)
+	}
+
+	res.StartupOrder = graphOrder(nodes, s.services.dependencies)
+	res.ShutdownOrder = make([]string, len(res.StartupOrder))
+	for i, id := range res.StartupOrder {
+		res.ShutdownOrder[len(res.StartupOrder)-(i+1)] = id
+	}
+
+	return res
+}
+
+// graphOrder topologically sorts nodes, dependencies first, breaking ties by
+// ID. Cycles, which NewServer reports but still builds a server for, are
+// broken ahead of their member of smallest ID, so that every node is placed.
+func graphOrder(nodes []netx.ServiceID, depMap map[netx.ServiceID][]netx.ServiceID) []string {
+	var (
+		res     = make([]string, 0, len(nodes))
+		pending = make(map[netx.ServiceID]int)
+		comps   map[netx.ServiceID]int
+	)
+
+	for _, id := range nodes {
+		pending[id] = len(depMap[id])
+	}
+
+	for len(res) < len(nodes) {
+		var next []netx.ServiceID
+
+		for _, id := range nodes {
+			if count, ok := pending[id]; ok && count == 0 {
+				next = append(next, id)
+				delete(pending, id)
+			}
+		}
+
+		if len(next) < 1 {
+			if comps == nil {
+				comps = cycleComponents(depMap, func(netx.ServiceID) bool { return true })
+			}
+
+			// Only cycles remain blocked, with their dependants
+			for _, id := range nodes {
+				if _, ok := pending[id]; ok && comps[id] > 0 {
+					next = append(next, id)
+					delete(pending, id)
+					break
+				}
+			}
+		}
+
+		for _, id := range next {
+			res = append(res, id.String())
+
+			for dependant := range pending {
+				for _, dep := range depMap[dependant] {
+					if dep == id {
+						pending[dependant]--
+					}
+				}
+			}
+		}
+	}
+
+	return res
+}
+
+// JSON TODO.
+func (g Graph) JSON() ([]byte, error) { return json.MarshalIndent(g, "", "  ") }
+
+// DOT TODO.
+func (g Graph) DOT() string {
+	var sb strings.Builder
+
+	sb.WriteString("digraph serverx {\n")
+	sb.WriteString("\tnode [shape=box];\n")
+
+	for _, svc := range g.Services {
+		label := []string{svc.ID}
+		for _, l := range svc.Listeners {
+			label = append(label, l.String())
+		}
+
+		fmt.Fprintf(&sb, "\t%s [label=%s];\n", graphQuote(svc.ID), graphQuote(strings.Join(label, "\n")))
+	}
+
+	for _, svc := range g.Services {
+		for _, dep := range svc.Dependencies {
+			fmt.Fprintf(&sb, "\t%s -> %s;\n", graphQuote(svc.ID), graphQuote(dep))
+		}
+	}
+
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+var graphQuoteReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func graphQuote(s string) string { return `"` + graphQuoteReplacer.Replace(s) + `"` }
@@ -0,0 +1,70 @@
+package serverx
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/oligarch316/go-netx/listenerx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerGraph(t *testing.T) {
+	l, err := listenerx.ListenInternal("test-server-graph", 0)
+	require.NoError(t, err)
+	defer l.Close()
+
+	svr, err := NewServer(
+		WithListeners(idA, l),
+		WithDependencies(idA, idB, idC),
+		WithDependencies(idB, idD),
+		WithDependencies(idC, idD),
+	)
+	require.NoError(t, err)
+
+	graph := svr.Graph()
+
+	assert.Equal(t, []string{"D", "B", "C", "A"}, graph.StartupOrder)
+	assert.Equal(t, []string{"A", "C", "B", "D"}, graph.ShutdownOrder)
+
+	require.Len(t, graph.Services, 4)
+	assert.Equal(t, []string{"B", "C"}, graph.Services[0].Dependencies)
+	assert.Equal(t, []GraphListener{{Network: "internal", Address: "test-server-graph"}}, graph.Services[0].Listeners)
+
+	// Named only as a dependency
+	assert.Equal(t, GraphService{ID: "D", Dependencies: []string{}, Listeners: []GraphListener{}}, graph.Services[3])
+
+	data, err := graph.JSON()
+	require.NoError(t, err)
+
+	var decoded Graph
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, graph, decoded)
+
+	assert.Equal(t, `digraph serverx {
+	node [shape=box];
+	"A" [label="A\ninternal://test-server-graph"];
+	"B" [label="B"];
+	"C" [label="C"];
+	"D" [label="D"];
+	"A" -> "B";
+	"A" -> "C";
+	"B" -> "D";
+	"C" -> "D";
+}
+`, graph.DOT())
+}
+
+func TestServerGraphCycle(t *testing.T) {
+	svr, err := NewServer(
+		WithDependencies(idA, idB),
+		WithDependencies(idB, idC),
+		WithDependencies(idC, idB, idD),
+	)
+	require.Error(t, err)
+
+	// The cycle is broken ahead of B, the rest ordered around it
+	graph := svr.Graph()
+	assert.Equal(t, []string{"D", "B", "A", "C"}, graph.StartupOrder)
+	assert.Equal(t, []string{"C", "A", "B", "D"}, graph.ShutdownOrder)
+}
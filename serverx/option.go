@@ -10,16 +10,41 @@ func WithListeners(id netx.ServiceID, ls ...netx.Listener) Option {
 	return func(p *Params) { p.Services.AppendListeners(id, ls...) }
 }
 
+// WithPacketListeners TODO.
+func WithPacketListeners(id netx.ServiceID, pls ...netx.PacketListener) Option {
+	return func(p *Params) { p.Services.AppendPacketListeners(id, pls...) }
+}
+
 // WithListenerOpts TODO.
 func WithListenerOpts(id netx.ServiceID, opts ...multi.ListenerOption) Option {
 	return func(p *Params) { p.Services.AppendListenerOpts(id, opts...) }
 }
 
+// WithDefaultListenerOpts TODO.
+func WithDefaultListenerOpts(opts ...multi.ListenerOption) Option {
+	return func(p *Params) { p.ListenerOpts = append(p.ListenerOpts, opts...) }
+}
+
 // WithDependencies TODO.
 func WithDependencies(id netx.ServiceID, deps ...netx.ServiceID) Option {
 	return func(p *Params) { p.Services.AppendDependencies(id, deps...) }
 }
 
+// WithAutoInternalListeners TODO.
+func WithAutoInternalListeners() Option {
+	return func(p *Params) { p.AutoInternalListeners = true }
+}
+
+// WithRunnerObserver TODO.
+func WithRunnerObserver(observer RunnerObserver) Option {
+	return func(p *Params) { p.RunnerObservers = append(p.RunnerObservers, observer) }
+}
+
+// WithRunnerEventHandler TODO.
+func WithRunnerEventHandler(handler multi.RunnerEventHandler) Option {
+	return func(p *Params) { p.RunnerEventHandlers = append(p.RunnerEventHandlers, handler) }
+}
+
 // WithIgnoreAll TODO.
 func WithIgnoreAll() Option {
 	return func(p *Params) {
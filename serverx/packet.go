@@ -0,0 +1,29 @@
+package serverx
+
+import (
+	"context"
+	"net"
+
+	"github.com/oligarch316/go-netx"
+)
+
+// packetDialer dials each packet listener in turn, for datagram networks a
+// failure here is local (e.g. a removed unixgram path) rather than remote.
+type packetDialer []netx.PacketListener
+
+func (pd packetDialer) Dial() (net.Conn, error) {
+	return pd.DialContext(context.Background())
+}
+
+func (pd packetDialer) DialContext(ctx context.Context) (net.Conn, error) {
+	var err error
+
+	for _, pl := range pd {
+		var conn net.Conn
+		if conn, err = pl.DialContext(ctx); err == nil {
+			return conn, nil
+		}
+	}
+
+	return nil, err
+}
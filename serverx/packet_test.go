@@ -0,0 +1,188 @@
+package serverx
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/oligarch316/go-netx"
+	"github.com/oligarch316/go-netx/listenerx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testOrder struct {
+	mu    sync.Mutex
+	items []netx.ServiceID
+}
+
+func (to *testOrder) add(id netx.ServiceID) {
+	to.mu.Lock()
+	defer to.mu.Unlock()
+	to.items = append(to.items, id)
+}
+
+type testEchoPacketService struct {
+	id    netx.ServiceID
+	order *testOrder
+}
+
+func (teps testEchoPacketService) ID() netx.ServiceID { return teps.id }
+
+func (teps testEchoPacketService) ServePacket(pc net.PacketConn) error {
+	buf := make([]byte, 512)
+	for {
+		n, addr, err := pc.ReadFrom(buf)
+		if err != nil {
+			return nil
+		}
+		pc.WriteTo(buf[:n], addr)
+	}
+}
+
+func (teps testEchoPacketService) Close(context.Context) error {
+	teps.order.add(teps.id)
+	return nil
+}
+
+type testStreamService struct {
+	id    netx.ServiceID
+	order *testOrder
+	l     chan net.Listener
+}
+
+func (tss testStreamService) ID() netx.ServiceID { return tss.id }
+
+func (tss testStreamService) Serve(l net.Listener) error {
+	tss.l <- l
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return nil
+		}
+		conn.Close()
+	}
+}
+
+func (tss testStreamService) Close(context.Context) error {
+	tss.order.add(tss.id)
+	return (<-tss.l).Close()
+}
+
+func TestServePacketService(t *testing.T) {
+	pl, err := listenerx.NewPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	svr, err := NewServer(
+		WithPacketListeners(idA, pl),
+		WithListeners(idB, listenerx.NewInternal(0)),
+		WithDependencies(idB, idA),
+	)
+	require.NoError(t, err)
+
+	var (
+		order     = new(testOrder)
+		packetSvc = testEchoPacketService{id: idA, order: order}
+		streamSvc = testStreamService{id: idB, order: order, l: make(chan net.Listener, 1)}
+	)
+
+	errs, err := svr.ServeWithPackets([]netx.Service{streamSvc}, packetSvc)
+	require.NoError(t, err)
+
+	dialer, err := svr.PacketDialer(idA)
+	require.NoError(t, err)
+
+	conn, err := dialer.Dial()
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = conn.Write([]byte("ping"))
+	require.NoError(t, err)
+
+	buf := make([]byte, 4)
+	_, err = conn.Read(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "ping", string(buf))
+
+	_, err = svr.PacketDialer(idB)
+	assert.Error(t, err)
+
+	svr.Close(context.Background())
+
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+
+	// Dependants close before their requirements
+	assert.Equal(t, []netx.ServiceID{idB, idA}, order.items)
+}
+
+func TestServeMissingPacketListener(t *testing.T) {
+	svr, err := NewServer()
+	require.NoError(t, err)
+
+	_, err = svr.ServeWithPackets(nil, testEchoPacketService{id: idA, order: new(testOrder)})
+	assert.ErrorIs(t, err, errMissingListener)
+}
+
+type testDualService struct {
+	testStreamService
+	packet testEchoPacketService
+}
+
+func (tds testDualService) ServePacket(pc net.PacketConn) error { return tds.packet.ServePacket(pc) }
+
+func TestServeDualService(t *testing.T) {
+	pl, err := listenerx.NewPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	svr, err := NewServer(
+		WithListeners(idA, listenerx.NewInternal(0)),
+		WithPacketListeners(idA, pl),
+	)
+	require.NoError(t, err)
+
+	var (
+		order = new(testOrder)
+		svc   = testDualService{
+			testStreamService: testStreamService{id: idA, order: order, l: make(chan net.Listener, 1)},
+			packet:            testEchoPacketService{id: idA, order: order},
+		}
+	)
+
+	// Served as a stream service, yet packet listeners are not lost
+	errs, err := svr.Serve(svc)
+	require.NoError(t, err)
+
+	streamDialer, err := svr.Dialer(idA)
+	require.NoError(t, err)
+
+	streamConn, err := streamDialer.Dial()
+	require.NoError(t, err)
+	streamConn.Close()
+
+	packetDialer, err := svr.PacketDialer(idA)
+	require.NoError(t, err)
+
+	packetConn, err := packetDialer.Dial()
+	require.NoError(t, err)
+	defer packetConn.Close()
+
+	_, err = packetConn.Write([]byte("ping"))
+	require.NoError(t, err)
+
+	buf := make([]byte, 4)
+	_, err = packetConn.Read(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "ping", string(buf))
+
+	svr.Close(context.Background())
+
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+
+	// Closed once, as a single service
+	assert.Equal(t, []netx.ServiceID{idA}, order.items)
+}
@@ -3,6 +3,8 @@ package serverx
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/oligarch316/go-netx"
 	"github.com/oligarch316/go-netx/runner"
@@ -19,12 +21,40 @@ const (
 	RunnerActionClose RunnerAction = "Close"
 )
 
+// RunnerState TODO.
+type RunnerState string
+
+const (
+	// RunnerStatePending TODO.
+	RunnerStatePending RunnerState = "pending"
+
+	// RunnerStateRunning TODO.
+	RunnerStateRunning RunnerState = "running"
+
+	// RunnerStateClosing TODO.
+	RunnerStateClosing RunnerState = "closing"
+
+	// RunnerStateDone TODO.
+	RunnerStateDone RunnerState = "done"
+)
+
+// RunnerStatus TODO.
+type RunnerStatus struct {
+	RunnerInfo
+	State RunnerState
+	Err   error
+}
+
 // RunnerInfo TODO.
 type RunnerInfo struct {
 	Name      string
 	ServiceID netx.ServiceID
 }
 
+// RunnerObserver is called once each Run or Close of a runner completes, with
+// the time taken and any resulting error.
+type RunnerObserver func(info RunnerInfo, action RunnerAction, dur time.Duration, err error)
+
 // RunnerError TODO.
 type RunnerError struct {
 	error
@@ -41,6 +71,11 @@ func (re RunnerError) Error() string {
 type serverRunner struct {
 	runner.Item
 	RunnerInfo
+	observer RunnerObserver
+
+	mu    sync.Mutex
+	state RunnerState
+	err   error
 }
 
 func newServerRunner(svcID netx.ServiceID, name string, rnr runner.Item) *serverRunner {
@@ -50,28 +85,76 @@ func newServerRunner(svcID netx.ServiceID, name string, rnr runner.Item) *server
 			Name:      name,
 			ServiceID: svcID,
 		},
+		state: RunnerStatePending,
 	}
 }
 
-func (sr serverRunner) Run() error {
-	if err := sr.Item.Run(); err != nil {
-		return RunnerError{
+func (sr *serverRunner) setState(state RunnerState, err error) {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+
+	// A closing runner stays closing until Run completes, and the first error
+	// observed is the one reported
+	if sr.state == RunnerStateDone || (sr.state == RunnerStateClosing && state == RunnerStateRunning) {
+		return
+	}
+
+	sr.state = state
+	if sr.err == nil {
+		sr.err = err
+	}
+}
+
+func (sr *serverRunner) status() RunnerStatus {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+	return RunnerStatus{RunnerInfo: sr.RunnerInfo, State: sr.state, Err: sr.err}
+}
+
+func (sr *serverRunner) observe(action RunnerAction, start time.Time, err error) {
+	if sr.observer != nil {
+		sr.observer(sr.RunnerInfo, action, time.Since(start), err)
+	}
+}
+
+func (sr *serverRunner) Run() error {
+	sr.setState(RunnerStateRunning, nil)
+
+	start := time.Now()
+	err := sr.Item.Run()
+	sr.observe(RunnerActionRun, start, err)
+
+	if err != nil {
+		runErr := RunnerError{
 			error:      err,
 			RunnerInfo: sr.RunnerInfo,
 			Action:     RunnerActionRun,
 		}
+
+		sr.setState(RunnerStateDone, runErr)
+		return runErr
 	}
 
+	sr.setState(RunnerStateDone, nil)
 	return nil
 }
 
-func (sr serverRunner) Close(ctx context.Context) error {
-	if err := sr.Item.Close(ctx); err != nil {
-		return RunnerError{
+func (sr *serverRunner) Close(ctx context.Context) error {
+	sr.setState(RunnerStateClosing, nil)
+
+	start := time.Now()
+	err := sr.Item.Close(ctx)
+	sr.observe(RunnerActionClose, start, err)
+
+	if err != nil {
+		closeErr := RunnerError{
 			error:      err,
 			RunnerInfo: sr.RunnerInfo,
 			Action:     RunnerActionClose,
 		}
+
+		sr.setState(RunnerStateDone, closeErr)
+		return closeErr
 	}
 
 	return nil
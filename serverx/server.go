@@ -4,8 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/oligarch316/go-netx"
+	"github.com/oligarch316/go-netx/listenerx"
 	"github.com/oligarch316/go-netx/listenerx/multi"
 	"github.com/oligarch316/go-netx/runner"
 )
@@ -21,11 +25,14 @@ type serviceParam struct {
 	dependencies map[netx.ServiceID]struct{}
 	listeners    []netx.Listener
 	listenerOpts []multi.ListenerOption
+
+	packetListeners []netx.PacketListener
 }
 
 type serviceData struct {
-	dependencies map[netx.ServiceID][]netx.ServiceID
-	listeners    map[netx.ServiceID]*multi.Listener
+	dependencies    map[netx.ServiceID][]netx.ServiceID
+	listeners       map[netx.ServiceID]*multi.Listener
+	packetListeners map[netx.ServiceID][]netx.PacketListener
 }
 
 // Option TODO.
@@ -33,8 +40,21 @@ type Option func(*Params)
 
 // Params TODO.
 type Params struct {
-	Ignore   IgnoreParams
-	Services ServiceParams
+	Ignore       IgnoreParams
+	Services     ServiceParams
+	ListenerOpts []multi.ListenerOption
+
+	// AutoInternalListeners provisions an internal listener for any stream
+	// service that would otherwise have none. Services are provisioned when
+	// configured, named as a dependency or served, not by lookups of unknown
+	// IDs.
+	AutoInternalListeners bool
+
+	RunnerObservers []RunnerObserver
+
+	// RunnerEventHandlers receive the runner events of every listener, in
+	// addition to any event handler set by listener options.
+	RunnerEventHandlers []multi.RunnerEventHandler
 }
 
 // IgnoreParams TODO.
@@ -47,10 +67,11 @@ type IgnoreParams struct {
 // ServiceParams TODO.
 type ServiceParams map[netx.ServiceID]*serviceParam
 
-func (sp ServiceParams) build() serviceData {
+func (sp ServiceParams) build(defaultOpts []multi.ListenerOption, finalOpt multi.ListenerOption, auto bool) serviceData {
 	res := serviceData{
-		dependencies: make(map[netx.ServiceID][]netx.ServiceID),
-		listeners:    make(map[netx.ServiceID]*multi.Listener),
+		dependencies:    make(map[netx.ServiceID][]netx.ServiceID),
+		listeners:       make(map[netx.ServiceID]*multi.Listener),
+		packetListeners: make(map[netx.ServiceID][]netx.PacketListener),
 	}
 
 	for id, param := range sp {
@@ -60,7 +81,45 @@ func (sp ServiceParams) build() serviceData {
 		}
 
 		res.dependencies[id] = deps
-		res.listeners[id] = multi.NewListener(param.listeners, param.listenerOpts...)
+		ls := param.listeners
+		if auto && len(ls) < 1 && len(param.packetListeners) < 1 {
+			ls = []netx.Listener{newAutoListener()}
+		}
+
+		opts := append(append([]multi.ListenerOption(nil), defaultOpts...), param.listenerOpts...)
+		opts = append(opts, finalOpt)
+		res.listeners[id] = multi.NewListener(ls, opts...)
+
+		if len(param.packetListeners) > 0 {
+			res.packetListeners[id] = param.packetListeners
+		}
+	}
+
+	if !auto {
+		return res
+	}
+
+	// Services named only as dependencies are provisioned too, so that they
+	// can be dialed ahead of Serve like any other
+	autoOpts := append(append([]multi.ListenerOption(nil), defaultOpts...), finalOpt)
+	for _, param := range sp {
+		for depID := range param.dependencies {
+			if _, ok := res.listeners[depID]; !ok {
+				res.addAuto(depID, autoOpts)
+			}
+		}
+	}
+
+	return res
+}
+
+// addAuto provisions an internal listener for id.
+func (sd serviceData) addAuto(id netx.ServiceID, opts []multi.ListenerOption) *multi.Listener {
+	res := multi.NewListener([]netx.Listener{newAutoListener()}, opts...)
+
+	sd.listeners[id] = res
+	if _, ok := sd.dependencies[id]; !ok {
+		sd.dependencies[id] = nil
 	}
 
 	return res
@@ -81,6 +140,12 @@ func (sp ServiceParams) AppendListeners(id netx.ServiceID, ls ...netx.Listener)
 	param.listeners = append(param.listeners, ls...)
 }
 
+// AppendPacketListeners TODO.
+func (sp ServiceParams) AppendPacketListeners(id netx.ServiceID, pls ...netx.PacketListener) {
+	param := sp.lookup(id)
+	param.packetListeners = append(param.packetListeners, pls...)
+}
+
 // AppendListenerOpts TODO.
 func (sp ServiceParams) AppendListenerOpts(id netx.ServiceID, opts ...multi.ListenerOption) {
 	param := sp.lookup(id)
@@ -98,8 +163,17 @@ func (sp ServiceParams) AppendDependencies(id netx.ServiceID, depIDs ...netx.Ser
 // Server TODO.
 type Server struct {
 	ignore   IgnoreParams
-	services serviceData
 	runGroup *runner.Group
+
+	auto         bool
+	listenerOpts []multi.ListenerOption
+	observer     RunnerObserver
+	servicesMu   sync.RWMutex
+	services     serviceData
+
+	mu         sync.Mutex
+	runners    []*serverRunner
+	closeHooks []func(context.Context)
 }
 
 // NewServer TODO.
@@ -117,39 +191,237 @@ func NewServer(opts ...Option) (*Server, error) {
 		opt(&params)
 	}
 
+	eventOpt := chainEventHandlers(params.RunnerEventHandlers)
+
 	res := &Server{
-		ignore:   params.Ignore,
-		services: params.Services.build(),
+		ignore:       params.Ignore,
+		auto:         params.AutoInternalListeners,
+		listenerOpts: append(append([]multi.ListenerOption(nil), params.ListenerOpts...), eventOpt),
+		observer:     combineObservers(params.RunnerObservers),
+		services:     params.Services.build(params.ListenerOpts, eventOpt, params.AutoInternalListeners),
 	}
 
 	return res, cycleCheck(res.services.dependencies)
 }
 
+// OnClose registers hook to be called once, at the start of Close and before
+// any runner is closed.
+func (s *Server) OnClose(hook func(context.Context)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closeHooks = append(s.closeHooks, hook)
+}
+
 // Close TODO.
 func (s *Server) Close(ctx context.Context) {
+	s.mu.Lock()
+	hooks := s.closeHooks
+	s.closeHooks = nil
+	s.mu.Unlock()
+
+	for _, hook := range hooks {
+		hook(ctx)
+	}
+
 	if s.runGroup != nil {
 		s.runGroup.Close(ctx)
 	}
 }
 
+func combineObservers(observers []RunnerObserver) RunnerObserver {
+	if len(observers) < 1 {
+		return nil
+	}
+
+	return func(info RunnerInfo, action RunnerAction, dur time.Duration, err error) {
+		for _, observer := range observers {
+			observer(info, action, dur, err)
+		}
+	}
+}
+
+// chainEventHandlers installs handlers after whatever event handler listener
+// options have set, so that neither replaces the other.
+func chainEventHandlers(handlers []multi.RunnerEventHandler) multi.ListenerOption {
+	return func(p *multi.ListenerParams) {
+		if len(handlers) < 1 {
+			return
+		}
+
+		prev := p.Runner.EventHandler
+		p.Runner.EventHandler = func(re multi.RunnerEvent) {
+			if prev != nil {
+				prev(re)
+			}
+			for _, handler := range handlers {
+				handler(re)
+			}
+		}
+	}
+}
+
+func newAutoListener() netx.Listener {
+	// Internal listeners cannot fail to be created
+	res, _ := listenerx.New(listenerx.InternalNetwork, "")
+	return res
+}
+
+func (s *Server) listener(id netx.ServiceID) (*multi.Listener, bool) {
+	s.servicesMu.RLock()
+	defer s.servicesMu.RUnlock()
+
+	ml, ok := s.services.listeners[id]
+	return ml, ok
+}
+
+// provisionListener returns the listener for id, provisioning one if automatic
+// internal listeners are enabled. Only services being served are provisioned
+// here, those configured or named as dependencies are by NewServer.
+func (s *Server) provisionListener(id netx.ServiceID) (*multi.Listener, bool) {
+	ml, ok := s.listener(id)
+
+	if ok || !s.auto {
+		return ml, ok
+	}
+
+	s.servicesMu.Lock()
+	defer s.servicesMu.Unlock()
+
+	if ml, ok = s.services.listeners[id]; !ok {
+		ml = s.services.addAuto(id, s.listenerOpts)
+	}
+
+	return ml, true
+}
+
+// ServiceIDs TODO.
+func (s *Server) ServiceIDs() []netx.ServiceID {
+	s.servicesMu.RLock()
+	defer s.servicesMu.RUnlock()
+	return s.serviceIDs()
+}
+
+// serviceIDs must be called with s.servicesMu held.
+func (s *Server) serviceIDs() []netx.ServiceID {
+	res := make(cycleIDList, 0, len(s.services.listeners))
+	for id := range s.services.listeners {
+		res = append(res, id)
+	}
+
+	sort.Stable(res)
+	return res
+}
+
+// Dependencies TODO.
+func (s *Server) Dependencies(id netx.ServiceID) ([]netx.ServiceID, error) {
+	s.servicesMu.RLock()
+	defer s.servicesMu.RUnlock()
+	return s.dependencies(id)
+}
+
+// dependencies must be called with s.servicesMu held.
+func (s *Server) dependencies(id netx.ServiceID) ([]netx.ServiceID, error) {
+	deps, ok := s.services.dependencies[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errNoSuchService, id)
+	}
+
+	res := append(cycleIDList(nil), deps...)
+	sort.Stable(res)
+	return res, nil
+}
+
+// Listener TODO.
+func (s *Server) Listener(id netx.ServiceID) (*multi.Listener, error) {
+	if ml, ok := s.listener(id); ok {
+		return ml, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", errNoSuchService, id)
+}
+
+// Runners TODO.
+func (s *Server) Runners() []RunnerStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res := make([]RunnerStatus, len(s.runners))
+	for i, item := range s.runners {
+		res[i] = item.status()
+	}
+	return res
+}
+
 // Dialer TODO.
 func (s *Server) Dialer(id netx.ServiceID) (*multi.Dialer, error) {
-	if ml, ok := s.services.listeners[id]; ok {
+	if ml, ok := s.listener(id); ok {
 		return ml.Dialer, nil
 	}
 
 	return nil, fmt.Errorf("%w: %s", errNoSuchService, id)
 }
 
+// PacketDialer TODO.
+func (s *Server) PacketDialer(id netx.ServiceID) (netx.Dialer, error) {
+	if pls, ok := s.services.packetListeners[id]; ok {
+		return packetDialer(pls), nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", errNoSuchService, id)
+}
+
+func (s *Server) newService(svc netx.BaseService) (*service, bool) {
+	id := svc.ID()
+
+	if packetSvc, ok := svc.(netx.PacketService); ok {
+		if pls, ok := s.services.packetListeners[id]; ok {
+			// Services of both kinds are served on their stream listeners too
+			if streamSvc, ok := svc.(netx.Service); ok {
+				if ml, ok := s.listener(id); ok && ml.Len() > 0 {
+					return newDualService(streamSvc, packetSvc, ml, pls), true
+				}
+			}
+
+			return newPacketService(packetSvc, pls), true
+		}
+	}
+
+	if streamSvc, ok := svc.(netx.Service); ok {
+		if ml, ok := s.provisionListener(id); ok {
+			return newService(streamSvc, ml), true
+		}
+	}
+
+	return nil, false
+}
+
 // Serve TODO.
 func (s *Server) Serve(svcs ...netx.Service) (<-chan error, error) {
+	return s.ServeWithPackets(svcs)
+}
+
+// ServeWithPackets is Serve for a mix of stream and packet services, served as
+// a single group in which either kind may depend on the other.
+func (s *Server) ServeWithPackets(svcs []netx.Service, pktSvcs ...netx.PacketService) (<-chan error, error) {
+	items := make([]netx.BaseService, 0, len(svcs)+len(pktSvcs))
+	for _, svc := range svcs {
+		items = append(items, svc)
+	}
+	for _, svc := range pktSvcs {
+		items = append(items, svc)
+	}
+
+	return s.serve(items)
+}
+
+func (s *Server) serve(svcs []netx.BaseService) (<-chan error, error) {
 	svcMap := make(map[netx.ServiceID]*service)
 
 	// Combine arguments with associated params to build finalized services
-	for _, svc := range svcs {
-		id := svc.ID()
+	for _, item := range svcs {
+		id := item.ID()
 
-		ml, ok := s.services.listeners[id]
+		svc, ok := s.newService(item)
 		if !ok {
 			if s.ignore.MissingListeners {
 				continue
@@ -162,12 +434,14 @@ func (s *Server) Serve(svcs ...netx.Service) (<-chan error, error) {
 			return nil, fmt.Errorf("%w: %s", errDuplicateService, id)
 		}
 
-		svcMap[id] = newService(svc, ml)
+		svcMap[id] = svc
 	}
 
 	// Build service dependencies
 	for id, svc := range svcMap {
-		for _, depID := range s.services.dependencies[id] {
+		deps, _ := s.Dependencies(id)
+
+		for _, depID := range deps {
 			depSvc, ok := svcMap[depID]
 			if !ok {
 				if s.ignore.MissingDependencies {
@@ -182,11 +456,34 @@ func (s *Server) Serve(svcs ...netx.Service) (<-chan error, error) {
 	}
 
 	// Build the run group from services
+	var runners []*serverRunner
+	for _, id := range sortedServiceIDs(svcMap) {
+		runners = append(runners, svcMap[id].Runners()...)
+	}
+
+	for _, item := range runners {
+		item.observer = s.observer
+	}
+
+	s.mu.Lock()
+	s.runners = runners
+	s.mu.Unlock()
+
 	s.runGroup = runner.NewGroup()
-	for _, svc := range svcMap {
-		s.runGroup.Append(svc.Runners()...)
+	for _, item := range runners {
+		s.runGroup.Append(item)
 	}
 
 	// Start the run group
 	return s.runGroup.Run(), nil
 }
+
+func sortedServiceIDs(svcMap map[netx.ServiceID]*service) []netx.ServiceID {
+	res := make(cycleIDList, 0, len(svcMap))
+	for id := range svcMap {
+		res = append(res, id)
+	}
+
+	sort.Stable(res)
+	return res
+}
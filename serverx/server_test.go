@@ -0,0 +1,69 @@
+package serverx
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/oligarch316/go-netx"
+	"github.com/oligarch316/go-netx/listenerx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServeAutoInternalListeners(t *testing.T) {
+	svr, err := NewServer(
+		WithAutoInternalListeners(),
+		WithDependencies(idA, idB),
+	)
+	require.NoError(t, err)
+
+	// Dialers are available ahead of Serve, for services configured or not
+	dialerB, err := svr.Dialer(idB)
+	require.NoError(t, err)
+	assert.Equal(t, []netx.ServiceID{idA, idB}, svr.ServiceIDs())
+
+	// But not for unknown services
+	_, err = svr.Dialer(idC)
+	assert.ErrorIs(t, err, errNoSuchService)
+	_, err = svr.Listener(idC)
+	assert.ErrorIs(t, err, errNoSuchService)
+	assert.Equal(t, []netx.ServiceID{idA, idB}, svr.ServiceIDs())
+
+	var (
+		order = new(testOrder)
+		svcA  = testStreamService{id: idA, order: order, l: make(chan net.Listener, 1)}
+		svcB  = testStreamService{id: idB, order: order, l: make(chan net.Listener, 1)}
+	)
+
+	errs, err := svr.Serve(svcA, svcB)
+	require.NoError(t, err)
+
+	for _, id := range []netx.ServiceID{idA, idB} {
+		ml, err := svr.Listener(id)
+		require.NoError(t, err)
+
+		addrs := ml.Resolve()
+		require.Len(t, addrs, 1)
+		assert.Equal(t, listenerx.InternalNetwork, addrs[0].Network())
+	}
+
+	conn, err := dialerB.Dial()
+	require.NoError(t, err)
+	conn.Close()
+
+	svr.Close(context.Background())
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, []netx.ServiceID{idA, idB}, order.items)
+}
+
+func TestServeMissingListener(t *testing.T) {
+	svr, err := NewServer()
+	require.NoError(t, err)
+
+	_, err = svr.Serve(testStreamService{id: idA, order: new(testOrder)})
+	assert.ErrorIs(t, err, errMissingListener)
+}
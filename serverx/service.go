@@ -3,6 +3,7 @@ package serverx
 import (
 	"context"
 	"fmt"
+	"net"
 
 	"github.com/oligarch316/go-netx"
 	"github.com/oligarch316/go-netx/listenerx/multi"
@@ -21,15 +22,92 @@ func (serviceNoopWG) Done() {}
 func (serviceNoopWG) Wait() {}
 
 type service struct {
-	svc netx.Service
-	ml  *multi.Listener
+	id            netx.ServiceID
+	serviceRunner runner.Item
+	listenRunners []*multi.MergeRunner
+	packet        bool
 
 	dependants, requirements []*service
 	dependantDoneSignal      func()
 }
 
 func newService(svc netx.Service, ml *multi.Listener) *service {
-	return &service{svc: svc, ml: ml}
+	return &service{
+		id:            svc.ID(),
+		serviceRunner: servicex.NewRunner(ml, svc),
+		listenRunners: ml.Runners(),
+	}
+}
+
+func newPacketService(svc netx.PacketService, pls []netx.PacketListener) *service {
+	conns := make([]net.PacketConn, len(pls))
+	for i, pl := range pls {
+		conns[i] = pl
+	}
+
+	return &service{
+		id:            svc.ID(),
+		serviceRunner: servicex.NewPacketRunner(conns, svc),
+		packet:        true,
+	}
+}
+
+// newDualService serves svc on both its stream and packet listeners, as one
+// service closed once.
+func newDualService(svc netx.Service, pktSvc netx.PacketService, ml *multi.Listener, pls []netx.PacketListener) *service {
+	var (
+		streamRunner = servicex.NewRunner(ml, svc)
+		packetRunner = newPacketService(pktSvc, pls).serviceRunner
+	)
+
+	closePackets := func() {
+		for _, pl := range pls {
+			pl.Close()
+		}
+	}
+
+	return &service{
+		id: svc.ID(),
+		serviceRunner: runner.New(
+			// Run
+			func() error {
+				var (
+					streamErr = make(chan error, 1)
+					packetErr = make(chan error, 1)
+				)
+
+				go func() { streamErr <- streamRunner.Run() }()
+				go func() { packetErr <- packetRunner.Run() }()
+
+				// Either side failing stops the other so that the error surfaces
+				select {
+				case err := <-streamErr:
+					if err != nil {
+						closePackets()
+					}
+					if perr := <-packetErr; err == nil {
+						err = perr
+					}
+					return err
+				case err := <-packetErr:
+					if err != nil {
+						ml.Close()
+					}
+					if serr := <-streamErr; err == nil {
+						err = serr
+					}
+					return err
+				}
+			},
+
+			// Close
+			func(ctx context.Context) error {
+				// Closes the service itself along with its packet conns
+				return packetRunner.Close(ctx)
+			},
+		),
+		listenRunners: ml.Runners(),
+	}
 }
 
 func (s *service) signalRequirements() {
@@ -43,8 +121,8 @@ func (s *service) DependOn(svc *service) {
 	svc.dependants = append(svc.dependants, s)
 }
 
-func (s *service) Runners() []runner.Item {
-	var res []runner.Item
+func (s *service) Runners() []*serverRunner {
+	var res []*serverRunner
 
 	// ----- "Glue" runners
 	// > wait groups for synchronization purposes
@@ -56,21 +134,29 @@ func (s *service) Runners() []runner.Item {
 	if len(s.dependants) > 0 {
 		rnr := runner.NewWaitGroup(len(s.dependants))
 		dependantsWG = rnr
-		res = append(res, newServerRunner(s.svc.ID(), "dependants wait group", rnr))
+		res = append(res, newServerRunner(s.id, "dependants wait group", rnr))
 	}
 
-	if s.ml.Len() > 0 {
-		rnr := runner.NewWaitGroup(s.ml.Len())
+	if len(s.listenRunners) > 0 {
+		rnr := runner.NewWaitGroup(len(s.listenRunners))
 		listenersWG = rnr
-		res = append(res, newServerRunner(s.svc.ID(), "listeners wait group", rnr))
+		res = append(res, newServerRunner(s.id, "listeners wait group", rnr))
 	}
 
 	s.dependantDoneSignal = dependantsWG.Done
 
+	// > stream services close once their listeners have stopped accepting
+	// > (itself gated on dependants), packet services have no listen runners
+	// > and so must wait on dependants directly
+	closeWG := listenersWG
+	if s.packet {
+		closeWG = dependantsWG
+	}
+
 	// ----- Service runner
 	// > svc.Serve(...) and svc.Close(...) wrapped with glue logic
 	var (
-		baseServiceRunner    = servicex.NewRunner(s.ml, s.svc)
+		baseServiceRunner    = s.serviceRunner
 		wrappedServiceRunner = runner.New(
 			// Run
 			func() error {
@@ -80,17 +166,17 @@ func (s *service) Runners() []runner.Item {
 
 			// Close
 			func(ctx context.Context) error {
-				listenersWG.Wait()
+				closeWG.Wait()
 				return baseServiceRunner.Close(ctx)
 			},
 		)
 	)
 
-	res = append(res, newServerRunner(s.svc.ID(), "service", wrappedServiceRunner))
+	res = append(res, newServerRunner(s.id, "service", wrappedServiceRunner))
 
 	// ----- Listener runners
 	// > ml.Runners() wrapped with glue logic
-	for _, item := range s.ml.Runners() {
+	for _, item := range s.listenRunners {
 		var (
 			baseListenRunner    = item
 			wrappedListenRunner = runner.New(
@@ -109,7 +195,7 @@ func (s *service) Runners() []runner.Item {
 		)
 
 		listenerName := fmt.Sprintf("listener (%s)", baseListenRunner.Addr())
-		res = append(res, newServerRunner(s.svc.ID(), listenerName, wrappedListenRunner))
+		res = append(res, newServerRunner(s.id, listenerName, wrappedListenRunner))
 	}
 
 	return res
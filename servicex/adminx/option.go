@@ -0,0 +1,44 @@
+package adminx
+
+import (
+	"github.com/oligarch316/go-netx"
+	"github.com/oligarch316/go-netx/listenerx/multi"
+	"github.com/oligarch316/go-netx/serverx"
+	"github.com/oligarch316/go-netx/servicex/httpx"
+)
+
+// ----- Server Options
+
+// WithListeners TODO.
+func WithListeners(ls ...netx.Listener) serverx.Option {
+	return serverx.WithListeners(ID, ls...)
+}
+
+// WithDependencies TODO.
+func WithDependencies(deps ...netx.ServiceID) serverx.Option {
+	return serverx.WithDependencies(ID, deps...)
+}
+
+// WithEventRecorder records the runner events of every listener. The recorder
+// is chained onto any event handler set by listener options, default or per
+// service, rather than replaced by it.
+func WithEventRecorder(recorder *multi.EventRecorder) serverx.Option {
+	return serverx.WithRunnerEventHandler(recorder.Record)
+}
+
+// WithConnTracking TODO.
+func WithConnTracking() serverx.Option {
+	return serverx.WithDefaultListenerOpts(multi.WithRunnerConnTracking(true))
+}
+
+// ----- Service Options
+
+// WithServiceEventRecorder TODO.
+func WithServiceEventRecorder(recorder *multi.EventRecorder) ServiceOption {
+	return func(p *ServiceParams) { p.EventRecorder = recorder }
+}
+
+// WithHTTPServiceOptions TODO.
+func WithHTTPServiceOptions(opts ...httpx.ServiceOption) ServiceOption {
+	return func(p *ServiceParams) { p.ServiceOptions = append(p.ServiceOptions, opts...) }
+}
@@ -0,0 +1,274 @@
+package adminx
+
+import (
+	"encoding/json"
+	"expvar"
+	"io"
+	"net/http"
+	"net/http/pprof"
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/oligarch316/go-netx"
+	"github.com/oligarch316/go-netx/listenerx/multi"
+	"github.com/oligarch316/go-netx/serverx"
+	"github.com/oligarch316/go-netx/servicex/httpx"
+)
+
+type namespace struct{}
+
+func (n namespace) String() string { return "adminx" }
+
+// ID TODO.
+var ID netx.ServiceID = namespace{}
+
+// ServiceOption TODO.
+type ServiceOption func(*ServiceParams)
+
+// ServiceParams TODO.
+type ServiceParams struct {
+	EventRecorder  *multi.EventRecorder
+	ServiceOptions []httpx.ServiceOption
+}
+
+// Service TODO.
+type Service struct {
+	*httpx.Service
+
+	params ServiceParams
+	server *serverx.Server
+}
+
+// NewService TODO.
+func NewService(svr *serverx.Server, opts ...ServiceOption) *Service {
+	var params ServiceParams
+	for _, opt := range opts {
+		opt(&params)
+	}
+
+	res := &Service{params: params, server: svr}
+
+	svcOpts := append(params.ServiceOptions, httpx.WithMuxHandlerFuncs(res.register))
+	res.Service = httpx.NewService(svcOpts...)
+
+	return res
+}
+
+// ID TODO.
+func (*Service) ID() netx.ServiceID { return ID }
+
+func (s *Service) register(mux *http.ServeMux) {
+	mux.HandleFunc("/services", s.handleServices)
+	mux.HandleFunc("/listeners", s.handleListeners)
+	mux.HandleFunc("/dependencies", s.handleDependencies)
+	mux.HandleFunc("/graph", s.handleGraph)
+	mux.HandleFunc("/graph.dot", s.handleGraphDOT)
+	mux.HandleFunc("/runners", s.handleRunners)
+	mux.HandleFunc("/events", s.handleEvents)
+	mux.HandleFunc("/connections", s.handleConnections)
+
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.Handle("/debug/vars", expvar.Handler())
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+func funcName(f interface{}) string {
+	v := reflect.ValueOf(f)
+	if !v.IsValid() || v.IsNil() {
+		return ""
+	}
+
+	name := runtime.FuncForPC(v.Pointer()).Name()
+	if idx := strings.LastIndex(name, "/"); idx >= 0 {
+		name = name[idx+1:]
+	}
+	return name
+}
+
+// ----- Documents
+
+// Addr TODO.
+type Addr struct {
+	Network string `json:"network"`
+	Address string `json:"address"`
+	Hash    string `json:"hash"`
+}
+
+// Listener TODO.
+type Listener struct {
+	Service  string          `json:"service"`
+	Addrs    []Addr          `json:"addrs"`
+	Strategy string          `json:"strategy"`
+	Retry    bool            `json:"retry"`
+	Stats    multi.ConnStats `json:"stats"`
+}
+
+// ServiceInfo TODO.
+type ServiceInfo struct {
+	ID           string   `json:"id"`
+	Dependencies []string `json:"dependencies"`
+	Listener     Listener `json:"listener"`
+}
+
+// Runner TODO.
+type Runner struct {
+	Service string `json:"service"`
+	Name    string `json:"name"`
+	State   string `json:"state"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Event TODO.
+type Event struct {
+	Time  time.Time `json:"time"`
+	Type  string    `json:"type"`
+	Addr  string    `json:"addr"`
+	Error string    `json:"error"`
+}
+
+func (s *Service) listener(id netx.ServiceID) Listener {
+	res := Listener{Service: id.String(), Addrs: []Addr{}}
+
+	ml, err := s.server.Listener(id)
+	if err != nil {
+		return res
+	}
+
+	for _, addr := range ml.Resolve() {
+		res.Addrs = append(res.Addrs, Addr{
+			Network: addr.Network(),
+			Address: addr.String(),
+			Hash:    addr.HashString(),
+		})
+	}
+
+	params := ml.Params()
+	res.Strategy = funcName(params.Strategy)
+	res.Retry = params.RetryDelay != nil
+	res.Stats = ml.Stats()
+
+	return res
+}
+
+func (s *Service) dependencies(id netx.ServiceID) []string {
+	deps, _ := s.server.Dependencies(id)
+
+	res := make([]string, len(deps))
+	for i, dep := range deps {
+		res[i] = dep.String()
+	}
+	return res
+}
+
+// ----- Handlers
+
+func (s *Service) handleServices(w http.ResponseWriter, r *http.Request) {
+	ids := s.server.ServiceIDs()
+	res := make([]ServiceInfo, len(ids))
+
+	for i, id := range ids {
+		res[i] = ServiceInfo{
+			ID:           id.String(),
+			Dependencies: s.dependencies(id),
+			Listener:     s.listener(id),
+		}
+	}
+
+	writeJSON(w, res)
+}
+
+func (s *Service) handleListeners(w http.ResponseWriter, r *http.Request) {
+	ids := s.server.ServiceIDs()
+	res := make([]Listener, len(ids))
+
+	for i, id := range ids {
+		res[i] = s.listener(id)
+	}
+
+	writeJSON(w, res)
+}
+
+func (s *Service) handleDependencies(w http.ResponseWriter, r *http.Request) {
+	res := make(map[string][]string)
+	for _, id := range s.server.ServiceIDs() {
+		res[id.String()] = s.dependencies(id)
+	}
+
+	writeJSON(w, res)
+}
+
+func (s *Service) handleGraph(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, s.server.Graph())
+}
+
+func (s *Service) handleGraphDOT(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/vnd.graphviz")
+	io.WriteString(w, s.server.Graph().DOT())
+}
+
+func (s *Service) handleRunners(w http.ResponseWriter, r *http.Request) {
+	statuses := s.server.Runners()
+	res := make([]Runner, len(statuses))
+
+	for i, status := range statuses {
+		res[i] = Runner{
+			Service: status.ServiceID.String(),
+			Name:    status.Name,
+			State:   string(status.State),
+			Error:   errString(status.Err),
+		}
+	}
+
+	writeJSON(w, res)
+}
+
+func (s *Service) handleEvents(w http.ResponseWriter, r *http.Request) {
+	res := []Event{}
+
+	if s.params.EventRecorder != nil {
+		for _, item := range s.params.EventRecorder.Events() {
+			res = append(res, Event{
+				Time:  item.Time,
+				Type:  strings.TrimPrefix(reflect.TypeOf(item.Event).Name(), "RunnerEvent"),
+				Addr:  item.Event.Addr().String(),
+				Error: errString(item.Event),
+			})
+		}
+	}
+
+	writeJSON(w, res)
+}
+
+func (s *Service) handleConnections(w http.ResponseWriter, r *http.Request) {
+	res := make(map[string]multi.ConnStats)
+	for _, id := range s.server.ServiceIDs() {
+		if ml, err := s.server.Listener(id); err == nil {
+			res[id.String()] = ml.Stats()
+		}
+	}
+
+	writeJSON(w, res)
+}
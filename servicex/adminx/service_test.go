@@ -0,0 +1,144 @@
+package adminx_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/oligarch316/go-netx/limitx"
+	"github.com/oligarch316/go-netx/listenerx"
+	"github.com/oligarch316/go-netx/listenerx/multi"
+	"github.com/oligarch316/go-netx/serverx"
+	"github.com/oligarch316/go-netx/servicex/adminx"
+	"github.com/oligarch316/go-netx/servicex/httpx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService(t *testing.T) {
+	recorder := multi.NewEventRecorder(16)
+
+	svr, err := serverx.NewServer(
+		httpx.WithListeners(listenerx.NewInternal(0)),
+		adminx.WithListeners(listenerx.NewInternal(0)),
+		adminx.WithDependencies(httpx.ID),
+		adminx.WithEventRecorder(recorder),
+		adminx.WithConnTracking(),
+	)
+	require.NoError(t, err)
+
+	var (
+		backend = httpx.NewService()
+		admin   = adminx.NewService(svr, adminx.WithServiceEventRecorder(recorder))
+	)
+
+	errs, err := svr.Serve(backend, admin)
+	require.NoError(t, err)
+
+	dialer, err := svr.Dialer(adminx.ID)
+	require.NoError(t, err)
+
+	client := &http.Client{Transport: httpx.NewTransport(dialer)}
+
+	get := func(path string, v interface{}) {
+		resp, err := client.Get("localapp://" + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		if v != nil {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(v))
+			return
+		}
+		io.Copy(io.Discard, resp.Body)
+	}
+
+	var services []adminx.ServiceInfo
+	get("/services", &services)
+	require.Len(t, services, 2)
+	assert.Equal(t, "adminx", services[0].ID)
+	assert.Equal(t, []string{"httpx"}, services[0].Dependencies)
+	assert.Equal(t, "multi.DialStrategyFirstOnly", services[0].Listener.Strategy)
+	require.Len(t, services[0].Listener.Addrs, 1)
+	assert.Equal(t, listenerx.InternalNetwork, services[0].Listener.Addrs[0].Network)
+
+	var runners []adminx.Runner
+	get("/runners", &runners)
+	require.NotEmpty(t, runners)
+	for _, item := range runners {
+		assert.Equal(t, "running", item.State, "%s %s", item.Service, item.Name)
+	}
+
+	var conns map[string]multi.ConnStats
+	get("/connections", &conns)
+	assert.Equal(t, int64(1), conns["adminx"].Active)
+	assert.Equal(t, int64(0), conns["httpx"].Accepted)
+
+	var events []adminx.Event
+	get("/events", &events)
+	assert.Empty(t, events)
+
+	var graph serverx.Graph
+	get("/graph", &graph)
+	assert.Equal(t, []string{"httpx", "adminx"}, graph.StartupOrder)
+
+	get("/graph.dot", nil)
+	get("/debug/vars", nil)
+	get("/debug/pprof/", nil)
+
+	client.CloseIdleConnections()
+	svr.Close(context.Background())
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+
+	for _, item := range svr.Runners() {
+		assert.Equal(t, serverx.RunnerStateDone, item.State)
+	}
+}
+
+func TestEventRecorderChained(t *testing.T) {
+	var (
+		recorder = multi.NewEventRecorder(16)
+		handled  = make(chan multi.RunnerEvent, 16)
+	)
+
+	svr, err := serverx.NewServer(
+		httpx.WithListeners(listenerx.NewInternal(0)),
+		adminx.WithEventRecorder(recorder),
+		serverx.WithListenerOpts(
+			httpx.ID,
+			multi.WithRunnerEventHandler(func(re multi.RunnerEvent) { handled <- re }),
+			multi.WithRunnerAcceptRate(limitx.Every(time.Hour, 1)),
+		),
+	)
+	require.NoError(t, err)
+
+	errs, err := svr.Serve(httpx.NewService())
+	require.NoError(t, err)
+
+	dialer, err := svr.Dialer(httpx.ID)
+	require.NoError(t, err)
+
+	// The second connection exceeds the accept rate and is shed
+	for i := 0; i < 2; i++ {
+		conn, err := dialer.Dial()
+		require.NoError(t, err)
+		conn.Close()
+	}
+
+	event := <-handled
+	assert.IsType(t, multi.RunnerEventAcceptRateLimited{}, event)
+
+	svr.Close(context.Background())
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+
+	recorded := recorder.Events()
+	require.Len(t, recorded, 1)
+	assert.Equal(t, event, recorded[0].Event)
+}
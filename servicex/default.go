@@ -2,3 +2,6 @@ package servicex
 
 // DefaultDialKey TODO.
 var DefaultDialKey = "localapp"
+
+// DefaultRegistryKey TODO.
+var DefaultRegistryKey = "localregistry"
@@ -0,0 +1,94 @@
+package grpcx
+
+import (
+	"fmt"
+
+	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/balancer/base"
+	"google.golang.org/grpc/balancer/roundrobin"
+	"google.golang.org/grpc/resolver"
+)
+
+const (
+	// BalancerPickFirst TODO.
+	BalancerPickFirst = "pick_first"
+
+	// BalancerRoundRobin TODO.
+	BalancerRoundRobin = roundrobin.Name
+
+	// BalancerPriority TODO.
+	BalancerPriority = "netx_priority"
+)
+
+func init() {
+	balancer.Register(base.NewBalancerBuilder(BalancerPriority, &blrPriorityBuilder{}, base.Config{}))
+}
+
+func blrServiceConfig(policy string) string {
+	return fmt.Sprintf(`{"loadBalancingConfig":[{"%s":{}}]}`, policy)
+}
+
+// ----- Address attributes
+
+type blrAttrKey int
+
+const (
+	blrAttrKeyNetwork blrAttrKey = iota
+	blrAttrKeyPriority
+)
+
+func blrSetAttributes(addr resolver.Address, network string, priority int) resolver.Address {
+	addr.BalancerAttributes = addr.BalancerAttributes.
+		WithValue(blrAttrKeyNetwork, network).
+		WithValue(blrAttrKeyPriority, priority)
+	return addr
+}
+
+// AddressNetwork TODO.
+func AddressNetwork(addr resolver.Address) (string, bool) {
+	res, ok := addr.BalancerAttributes.Value(blrAttrKeyNetwork).(string)
+	return res, ok
+}
+
+// AddressPriority TODO.
+func AddressPriority(addr resolver.Address) (int, bool) {
+	res, ok := addr.BalancerAttributes.Value(blrAttrKeyPriority).(int)
+	return res, ok
+}
+
+// ----- Priority balancer
+
+type blrPriorityBuilder struct{}
+
+func (*blrPriorityBuilder) Build(info base.PickerBuildInfo) balancer.Picker {
+	var (
+		res         balancer.SubConn
+		resPriority int
+	)
+
+	// Pick the ready sub connection whose address came earliest in the
+	// resolved (and therefore addressx ordered) address list. Addresses
+	// lacking a priority attribute are only used as a last resort.
+	for sc, scInfo := range info.ReadySCs {
+		priority, ok := AddressPriority(scInfo.Address)
+		if !ok {
+			priority = int(^uint(0) >> 1)
+		}
+
+		if res == nil || priority < resPriority {
+			res, resPriority = sc, priority
+		}
+	}
+
+	if res == nil {
+		return base.NewErrPicker(balancer.ErrNoSubConnAvailable)
+	}
+
+	return &blrPriorityPicker{subConn: res}
+}
+
+type blrPriorityPicker struct{ subConn balancer.SubConn }
+
+func (bpp *blrPriorityPicker) Pick(balancer.PickInfo) (balancer.PickResult, error) {
+	return balancer.PickResult{SubConn: bpp.subConn}, nil
+}
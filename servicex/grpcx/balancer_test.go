@@ -0,0 +1,150 @@
+package grpcx
+
+import (
+	"testing"
+
+	"github.com/oligarch316/go-netx"
+	"github.com/oligarch316/go-netx/listenerx"
+	"github.com/oligarch316/go-netx/listenerx/multi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/balancer/base"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/serviceconfig"
+)
+
+type mockSubConn struct {
+	balancer.SubConn
+	name string
+}
+
+type mockClientConn struct {
+	resolver.ClientConn
+	states chan resolver.State
+	errs   chan error
+}
+
+func newMockClientConn() *mockClientConn {
+	return &mockClientConn{
+		states: make(chan resolver.State, 8),
+		errs:   make(chan error, 8),
+	}
+}
+
+func (mcc *mockClientConn) UpdateState(state resolver.State) error {
+	mcc.states <- state
+	return nil
+}
+
+func (mcc *mockClientConn) ReportError(err error) { mcc.errs <- err }
+
+func (*mockClientConn) ParseServiceConfig(string) *serviceconfig.ParseResult {
+	return new(serviceconfig.ParseResult)
+}
+
+func TestAddressAttributes(t *testing.T) {
+	addr := blrSetAttributes(resolver.Address{Addr: "a"}, "unix", 3)
+
+	network, ok := AddressNetwork(addr)
+	assert.True(t, ok)
+	assert.Equal(t, "unix", network)
+
+	priority, ok := AddressPriority(addr)
+	assert.True(t, ok)
+	assert.Equal(t, 3, priority)
+
+	_, ok = AddressNetwork(resolver.Address{Addr: "b"})
+	assert.False(t, ok)
+
+	_, ok = AddressPriority(resolver.Address{Addr: "b"})
+	assert.False(t, ok)
+}
+
+func TestPriorityPicker(t *testing.T) {
+	var (
+		low  = &mockSubConn{name: "low"}
+		high = &mockSubConn{name: "high"}
+		bare = &mockSubConn{name: "bare"}
+	)
+
+	scInfo := func(priority int) base.SubConnInfo {
+		addr := resolver.Address{Addr: "x"}
+		if priority >= 0 {
+			addr = blrSetAttributes(addr, "tcp", priority)
+		}
+		return base.SubConnInfo{Address: addr}
+	}
+
+	subtests := []struct {
+		name     string
+		ready    map[balancer.SubConn]base.SubConnInfo
+		expected balancer.SubConn
+	}{
+		{
+			name:     "lowest priority value wins",
+			ready:    map[balancer.SubConn]base.SubConnInfo{low: scInfo(2), high: scInfo(0), bare: scInfo(-1)},
+			expected: high,
+		},
+		{
+			name:     "unprioritized as last resort",
+			ready:    map[balancer.SubConn]base.SubConnInfo{bare: scInfo(-1)},
+			expected: bare,
+		},
+		{
+			name:     "none ready",
+			ready:    map[balancer.SubConn]base.SubConnInfo{},
+			expected: nil,
+		},
+	}
+
+	for _, item := range subtests {
+		subtest := item
+
+		t.Run(subtest.name, func(t *testing.T) {
+			t.Parallel()
+
+			picker := new(blrPriorityBuilder).Build(base.PickerBuildInfo{ReadySCs: subtest.ready})
+			res, err := picker.Pick(balancer.PickInfo{})
+
+			if subtest.expected == nil {
+				assert.ErrorIs(t, err, balancer.ErrNoSubConnAvailable)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, subtest.expected, res.SubConn)
+		})
+	}
+}
+
+func TestResolverAddressAttributes(t *testing.T) {
+	unix, err := listenerx.NewUnix(t.TempDir() + "/app.sock")
+	require.NoError(t, err)
+	defer unix.Close()
+
+	ml := multi.NewListener([]netx.Listener{unix, listenerx.NewInternal(0)})
+
+	var (
+		cc      = newMockClientConn()
+		builder = rsvBuilder{hResolver: ml.Dialer, policy: BalancerPriority}
+	)
+
+	_, err = builder.build(cc)
+	require.NoError(t, err)
+
+	state := <-cc.states
+	require.Len(t, state.Addresses, 2)
+	assert.NotNil(t, state.ServiceConfig)
+
+	// Addresses follow the default ordering, internal before unix
+	for i, expected := range []string{listenerx.InternalNetwork, "unix"} {
+		network, ok := AddressNetwork(state.Addresses[i])
+		assert.True(t, ok)
+		assert.Equal(t, expected, network)
+
+		priority, ok := AddressPriority(state.Addresses[i])
+		assert.True(t, ok)
+		assert.Equal(t, i, priority)
+	}
+}
@@ -4,13 +4,43 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"strings"
 
+	"github.com/oligarch316/go-netx"
+	"github.com/oligarch316/go-netx/addressx"
 	"github.com/oligarch316/go-netx/listenerx/multi"
 	"github.com/oligarch316/go-netx/serverx"
 	"github.com/oligarch316/go-netx/servicex"
+	"github.com/oligarch316/go-netx/tracex"
 	"google.golang.org/grpc"
 )
 
+const (
+	dialPrefixUnix         = "unix:"
+	dialPrefixUnixAbsolute = "unix://"
+	dialPrefixUnixAbstract = "unix-abstract:"
+)
+
+// dialParseAddr determines the network of a non-local address as handed to a
+// custom dialer by grpc. Addresses produced by grpc's own "unix" resolver
+// arrive as "unix://<absolute path>" or "unix:<relative path>", while those
+// produced by its "unix-abstract" resolver arrive with a leading null byte.
+// Anything else is assumed to be a "tcp" address.
+func dialParseAddr(addr string) (network, address string) {
+	switch {
+	case strings.HasPrefix(addr, "\x00"):
+		return "unix", "@" + addr[1:]
+	case strings.HasPrefix(addr, dialPrefixUnixAbstract):
+		return "unix", "@" + addr[len(dialPrefixUnixAbstract):]
+	case strings.HasPrefix(addr, dialPrefixUnixAbsolute):
+		return "unix", addr[len(dialPrefixUnixAbsolute):]
+	case strings.HasPrefix(addr, dialPrefixUnix):
+		return "unix", addr[len(dialPrefixUnix):]
+	default:
+		return "tcp", addr
+	}
+}
+
 type hashDialer interface {
 	DialHash(multi.SetHash) (net.Conn, error)
 	DialContextHash(context.Context, multi.SetHash) (net.Conn, error)
@@ -27,18 +57,40 @@ type DialerOption func(*DialerParams)
 
 // DialerParams TODO.
 type DialerParams struct {
+	// ServiceID selects the service dialed by LoadDialer.
+	ServiceID netx.ServiceID
+
 	Resolver        ResolverParams
+	Registry        RegistryParams
+	FallbackDialer  func(context.Context, string, string) (net.Conn, error)
 	GRPCDialOptions []grpc.DialOption
+
+	// Tracer, if set, traces every call and propagates the span context to the
+	// service via metadata.
+	Tracer tracex.Tracer
 }
 
 func defaultDialerParams() DialerParams {
-	schemeName := servicex.DefaultDialKey
+	var (
+		schemeName         = servicex.DefaultDialKey
+		registrySchemeName = servicex.DefaultRegistryKey
+	)
 
 	return DialerParams{
+		ServiceID: ID,
 		Resolver: ResolverParams{
-			SchemeName:  &schemeName,
-			DNSHostName: nil,
+			SchemeName:     &schemeName,
+			DNSHostName:    nil,
+			BalancerPolicy: BalancerPriority,
 		},
+		Registry: RegistryParams{
+			File:       nil,
+			SchemeName: registrySchemeName,
+			AddressOrdering: addressx.Ordering{
+				addressx.ByPriorityNetwork("unix", "tcp"),
+			},
+		},
+		FallbackDialer:  (&net.Dialer{}).DialContext,
 		GRPCDialOptions: nil,
 	}
 }
@@ -52,30 +104,32 @@ func (dp DialerParams) buildContextDialer(hDialer hashDialer) func(context.Conte
 			return hDialer.DialContextHash(ctx, h)
 		}
 
-		// TODO: Manage network string better than a hardcoded "tcp"
-		//
-		// Both the interface and implementation for custom dialers limit our
-		// ability here to do the correct thing in terms of network. Given that
-		// stock dialing behavior deals only with "tcp" and "unix" networks,
-		// hardcoding "tcp" here only precludes dialing to a unix socket on the
-		// same machine but not one used by this same app. The use case for
-		// running multiple server processes on the same machine that may want
-		// to dial themselves or dial each other is esoteric enough to be the
-		// lesser of all evils.
-		//
-		// Related issue:                    https://github.com/grpc/grpc-go/issues/3990
-		// Better-but-not-great related fix: https://github.com/grpc/grpc-go/pull/4021/
-
-		return (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+		network, address := dialParseAddr(addr)
+		return dp.FallbackDialer(ctx, network, address)
 	}
 }
 
 func (dp DialerParams) build(dialSet DialSet) []grpc.DialOption {
-	return append(
+	resolvers := append(
+		dp.Resolver.build(dialSet),
+		dp.Registry.build(dp.Resolver.BalancerPolicy)...,
+	)
+
+	res := append(
 		dp.GRPCDialOptions,
-		grpc.WithResolvers(dp.Resolver.build(dialSet)...),
+		grpc.WithResolvers(resolvers...),
 		grpc.WithContextDialer(dp.buildContextDialer(dialSet)),
 	)
+
+	if dp.Tracer != nil {
+		res = append(
+			res,
+			grpc.WithChainUnaryInterceptor(traceUnaryClientInterceptor(dp.Tracer)),
+			grpc.WithChainStreamInterceptor(traceStreamClientInterceptor(dp.Tracer)),
+		)
+	}
+
+	return res
 }
 
 // Dialer TODO.
@@ -83,7 +137,12 @@ type Dialer struct{ commonOpts []grpc.DialOption }
 
 // LoadDialer TODO.
 func LoadDialer(svr *serverx.Server, opts ...DialerOption) (*Dialer, error) {
-	dialSet, err := svr.Dialer(ID)
+	params := defaultDialerParams()
+	for _, opt := range opts {
+		opt(&params)
+	}
+
+	dialSet, err := svr.Dialer(params.ServiceID)
 	if err != nil {
 		return nil, err
 	}
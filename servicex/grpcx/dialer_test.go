@@ -0,0 +1,57 @@
+package grpcx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDialerParseAddr(t *testing.T) {
+	subtests := []struct {
+		name, addr                       string
+		expectedNetwork, expectedAddress string
+	}{
+		{
+			name:            "tcp",
+			addr:            "localhost:8080",
+			expectedNetwork: "tcp",
+			expectedAddress: "localhost:8080",
+		},
+		{
+			name:            "unix absolute",
+			addr:            "unix:///run/app.sock",
+			expectedNetwork: "unix",
+			expectedAddress: "/run/app.sock",
+		},
+		{
+			name:            "unix relative",
+			addr:            "unix:app.sock",
+			expectedNetwork: "unix",
+			expectedAddress: "app.sock",
+		},
+		{
+			name:            "unix abstract null byte",
+			addr:            "\x00app",
+			expectedNetwork: "unix",
+			expectedAddress: "@app",
+		},
+		{
+			name:            "unix abstract scheme",
+			addr:            "unix-abstract:app",
+			expectedNetwork: "unix",
+			expectedAddress: "@app",
+		},
+	}
+
+	for _, item := range subtests {
+		subtest := item
+
+		t.Run(subtest.name, func(t *testing.T) {
+			t.Parallel()
+
+			network, address := dialParseAddr(subtest.addr)
+			assert.Equal(t, subtest.expectedNetwork, network)
+			assert.Equal(t, subtest.expectedAddress, address)
+		})
+	}
+}
@@ -1,8 +1,15 @@
 package grpcx
 
 import (
+	"context"
+	"net"
+
 	"github.com/oligarch316/go-netx"
+	"github.com/oligarch316/go-netx/addressx"
+	"github.com/oligarch316/go-netx/limitx"
+	"github.com/oligarch316/go-netx/registryx"
 	"github.com/oligarch316/go-netx/serverx"
+	"github.com/oligarch316/go-netx/tracex"
 	"google.golang.org/grpc"
 )
 
@@ -18,8 +25,36 @@ func WithDependencies(deps ...netx.ServiceID) serverx.Option {
 	return serverx.WithDependencies(ID, deps...)
 }
 
+// WithIDListeners is WithListeners for the service given WithID, with an id
+// made by NewID.
+func WithIDListeners(id netx.ServiceID, ls ...netx.Listener) serverx.Option {
+	return serverx.WithListeners(id, ls...)
+}
+
+// WithIDDependencies is WithDependencies for the service given WithID, with an
+// id made by NewID.
+func WithIDDependencies(id netx.ServiceID, deps ...netx.ServiceID) serverx.Option {
+	return serverx.WithDependencies(id, deps...)
+}
+
 // ----- Service Options
 
+// WithID TODO.
+func WithID(id netx.ServiceID) ServiceOption {
+	return func(p *ServiceParams) { p.ID = id }
+}
+
+// WithTracer TODO.
+func WithTracer(tracer tracex.Tracer) ServiceOption {
+	return func(p *ServiceParams) { p.Tracer = tracer }
+}
+
+// WithRateLimit TODO.
+func WithRateLimit(rate limitx.Rate, opts ...RateLimitOption) ServiceOption {
+	unary, stream := RateLimitInterceptors(rate, opts...)
+	return WithGRPCServerOptions(grpc.ChainUnaryInterceptor(unary), grpc.ChainStreamInterceptor(stream))
+}
+
 // WithGRPCServerOptions TODO.
 func WithGRPCServerOptions(opts ...grpc.ServerOption) ServiceOption {
 	return func(p *ServiceParams) { p.GRPCServerOptions = append(p.GRPCServerOptions, opts...) }
@@ -39,13 +74,45 @@ func WithHandlerFuncs(fs ...func(*grpc.Server)) ServiceOption {
 	return WithHandlers(hs...)
 }
 
+// ----- Rate Limit Options
+
+// WithRateLimitKey TODO.
+func WithRateLimitKey(key RateLimitKeyFunc) RateLimitOption {
+	return func(p *RateLimitParams) { p.Key = key }
+}
+
+// WithRateLimitMaxKeys TODO.
+func WithRateLimitMaxKeys(n int) RateLimitOption {
+	return func(p *RateLimitParams) { p.MaxKeys = n }
+}
+
+// WithRateLimitEventHandler TODO.
+func WithRateLimitEventHandler(handler func(RateLimitEvent)) RateLimitOption {
+	return func(p *RateLimitParams) { p.EventHandler = handler }
+}
+
 // ----- Dialer Options
 
+// WithDialerID TODO.
+func WithDialerID(id netx.ServiceID) DialerOption {
+	return func(p *DialerParams) { p.ServiceID = id }
+}
+
+// WithDialerTracer TODO.
+func WithDialerTracer(tracer tracex.Tracer) DialerOption {
+	return func(p *DialerParams) { p.Tracer = tracer }
+}
+
 // WithGRPCDialOptions TODO.
 func WithGRPCDialOptions(opts ...grpc.DialOption) DialerOption {
 	return func(p *DialerParams) { p.GRPCDialOptions = append(p.GRPCDialOptions, opts...) }
 }
 
+// WithFallbackDialer TODO.
+func WithFallbackDialer(f func(ctx context.Context, network, address string) (net.Conn, error)) DialerOption {
+	return func(p *DialerParams) { p.FallbackDialer = f }
+}
+
 // WithResolveNoScheme TODO.
 func WithResolveNoScheme(p *DialerParams) { p.Resolver.SchemeName = nil }
 
@@ -61,3 +128,27 @@ func WithResolveSchemeName(name string) DialerOption {
 func WithResolveDNSHostName(name string) DialerOption {
 	return func(p *DialerParams) { p.Resolver.DNSHostName = &name }
 }
+
+// WithBalancerPolicy TODO.
+//
+// The default policy is BalancerPriority, which prefers local addresses in
+// dial order, rather than grpc's implicit pick_first. Passing
+// BalancerPickFirst restores the grpc default.
+func WithBalancerPolicy(name string) DialerOption {
+	return func(p *DialerParams) { p.Resolver.BalancerPolicy = name }
+}
+
+// WithRegistry TODO.
+func WithRegistry(f *registryx.File) DialerOption {
+	return func(p *DialerParams) { p.Registry.File = f }
+}
+
+// WithRegistrySchemeName TODO.
+func WithRegistrySchemeName(name string) DialerOption {
+	return func(p *DialerParams) { p.Registry.SchemeName = name }
+}
+
+// WithRegistryAddressOrdering TODO.
+func WithRegistryAddressOrdering(ordering addressx.Ordering) DialerOption {
+	return func(p *DialerParams) { p.Registry.AddressOrdering = ordering }
+}
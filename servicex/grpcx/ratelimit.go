@@ -0,0 +1,107 @@
+package grpcx
+
+import (
+	"context"
+	"time"
+
+	"github.com/oligarch316/go-netx/limitx"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// RateLimitKeyFunc TODO.
+type RateLimitKeyFunc func(ctx context.Context, fullMethod string) string
+
+// RateLimitKeyMethod TODO.
+func RateLimitKeyMethod(_ context.Context, fullMethod string) string { return fullMethod }
+
+// RateLimitKeyMetadata keys calls by the first value of the named incoming
+// metadata entry. The entry is set by the client, so unless a trusted proxy
+// sets it each client may pick as many keys as it likes: use only behind such
+// a proxy, and see MaxKeys.
+func RateLimitKeyMetadata(name string) RateLimitKeyFunc {
+	return func(ctx context.Context, _ string) string {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if vals := md.Get(name); len(vals) > 0 {
+			return vals[0]
+		}
+		return ""
+	}
+}
+
+// RateLimitEvent TODO.
+type RateLimitEvent struct {
+	Key        string
+	FullMethod string
+	RetryAfter time.Duration
+}
+
+// RateLimitOption TODO.
+type RateLimitOption func(*RateLimitParams)
+
+// RateLimitParams TODO.
+type RateLimitParams struct {
+	Rate         limitx.Rate
+	Key          RateLimitKeyFunc
+	EventHandler func(RateLimitEvent)
+
+	// MaxKeys caps the keys limited independently, beyond which new keys share
+	// a single limit, zero or less for no cap.
+	MaxKeys int
+}
+
+func defaultRateLimitParams(rate limitx.Rate) RateLimitParams {
+	return RateLimitParams{
+		Rate:         rate,
+		Key:          RateLimitKeyMethod,
+		EventHandler: func(RateLimitEvent) {},
+		MaxKeys:      limitx.DefaultMaxKeys,
+	}
+}
+
+type rateLimiter struct {
+	params  RateLimitParams
+	limiter *limitx.Keyed
+}
+
+func (rl rateLimiter) allow(ctx context.Context, fullMethod string) error {
+	key := rl.params.Key(ctx, fullMethod)
+
+	ok, wait := rl.limiter.Allow(key)
+	if ok {
+		return nil
+	}
+
+	rl.params.EventHandler(RateLimitEvent{Key: key, FullMethod: fullMethod, RetryAfter: wait})
+	return status.Errorf(codes.ResourceExhausted, "grpcx: rate limit exceeded, retry after %s", wait)
+}
+
+// RateLimitInterceptors returns unary and stream interceptors sharing a
+// single limiter, shedding calls exceeding rate per key with a
+// ResourceExhausted status.
+func RateLimitInterceptors(rate limitx.Rate, opts ...RateLimitOption) (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+	params := defaultRateLimitParams(rate)
+	for _, opt := range opts {
+		opt(&params)
+	}
+
+	rl := rateLimiter{params: params, limiter: limitx.NewKeyed(params.Rate, params.MaxKeys)}
+
+	unary := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := rl.allow(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+
+	stream := func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := rl.allow(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+
+	return unary, stream
+}
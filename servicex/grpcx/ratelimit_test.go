@@ -0,0 +1,66 @@
+package grpcx_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/oligarch316/go-netx/limitx"
+	"github.com/oligarch316/go-netx/listenerx"
+	"github.com/oligarch316/go-netx/serverx"
+	"github.com/oligarch316/go-netx/servicex/grpcx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestRateLimit(t *testing.T) {
+	var events []grpcx.RateLimitEvent
+
+	svr, err := serverx.NewServer(grpcx.WithListeners(listenerx.NewInternal(0)))
+	require.NoError(t, err)
+
+	svc := grpcx.NewService(
+		grpcx.WithRateLimit(
+			limitx.Rate{Limit: 1e-9, Burst: 1},
+			grpcx.WithRateLimitKey(grpcx.RateLimitKeyMetadata("client")),
+			grpcx.WithRateLimitEventHandler(func(e grpcx.RateLimitEvent) { events = append(events, e) }),
+		),
+		grpcx.WithHandlerFuncs(func(s *grpc.Server) { healthpb.RegisterHealthServer(s, health.NewServer()) }),
+	)
+
+	errs, err := svr.Serve(svc)
+	require.NoError(t, err)
+
+	dialer, err := grpcx.LoadDialer(svr)
+	require.NoError(t, err)
+
+	conn, err := dialer.Dial("localapp:///", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+
+	check := func(client string) error {
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "client", client)
+		_, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+		return err
+	}
+
+	assert.NoError(t, check("a"))
+	assert.NoError(t, check("b"), "expected independent keys")
+	assert.Equal(t, codes.ResourceExhausted, status.Code(check("a")))
+
+	conn.Close()
+	svr.Close(context.Background())
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, "a", events[0].Key)
+		assert.Equal(t, "/grpc.health.v1.Health/Check", events[0].FullMethod)
+	}
+}
@@ -0,0 +1,136 @@
+package grpcx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/oligarch316/go-netx/addressx"
+	"github.com/oligarch316/go-netx/registryx"
+	"google.golang.org/grpc/resolver"
+)
+
+// RegistryParams TODO.
+type RegistryParams struct {
+	File            *registryx.File
+	SchemeName      string
+	AddressOrdering addressx.Ordering
+}
+
+func (rp RegistryParams) build(policy string) []resolver.Builder {
+	if rp.File == nil {
+		return nil
+	}
+
+	return []resolver.Builder{&rsvBuilderRegistry{params: rp, policy: policy}}
+}
+
+func rsvFormatEntry(entry registryx.Entry) string {
+	if entry.Network != "unix" {
+		return entry.Address
+	}
+
+	switch {
+	case strings.HasPrefix(entry.Address, "@"):
+		return dialPrefixUnixAbstract + entry.Address[1:]
+	case filepath.IsAbs(entry.Address):
+		return dialPrefixUnixAbsolute + entry.Address
+	default:
+		return dialPrefixUnix + entry.Address
+	}
+}
+
+type rsvBuilderRegistry struct {
+	params RegistryParams
+	policy string
+}
+
+func (rbr *rsvBuilderRegistry) Scheme() string { return rbr.params.SchemeName }
+
+func (rbr *rsvBuilderRegistry) Build(target resolver.Target, cc resolver.ClientConn, _ resolver.BuildOptions) (resolver.Resolver, error) {
+	service := strings.TrimPrefix(target.URL.Path, "/")
+	if service == "" {
+		service = target.URL.Opaque
+	}
+
+	if service == "" {
+		return nil, fmt.Errorf("grpcx: %s resolver: missing service id", rbr.params.SchemeName)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	res := &rsvRegistry{
+		params:  rbr.params,
+		policy:  rbr.policy,
+		service: service,
+		cc:      cc,
+		cancel:  cancel,
+	}
+
+	go rbr.params.File.Watch(ctx, res.update)
+	return res, nil
+}
+
+type rsvRegistry struct {
+	params  RegistryParams
+	policy  string
+	service string
+
+	mu     sync.Mutex
+	cc     resolver.ClientConn
+	cancel context.CancelFunc
+	closed bool
+}
+
+func (rr *rsvRegistry) update(doc registryx.Document, err error) {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+
+	// Updates run asynchronously and may land after Close, at which point the
+	// ClientConn must no longer be called
+	if rr.closed {
+		return
+	}
+
+	if err != nil {
+		rr.cc.ReportError(err)
+		return
+	}
+
+	entries := doc.Lookup(rr.service)
+	if len(entries) < 1 {
+		rr.cc.ReportError(errors.New("no registry addresses"))
+		return
+	}
+
+	registryx.SortEntries(entries, rr.params.AddressOrdering)
+
+	rsvAddrs := make([]resolver.Address, len(entries))
+	for i, entry := range entries {
+		rsvAddr := resolver.Address{Addr: rsvFormatEntry(entry)}
+		rsvAddrs[i] = blrSetAttributes(rsvAddr, entry.Network, i)
+	}
+
+	state, err := rsvState(rr.cc, rr.policy, rsvAddrs)
+	if err != nil {
+		rr.cc.ReportError(err)
+		return
+	}
+
+	rr.cc.UpdateState(state)
+}
+
+func (rr *rsvRegistry) ResolveNow(resolver.ResolveNowOptions) {
+	go rr.update(rr.params.File.Load())
+}
+
+func (rr *rsvRegistry) Close() {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+
+	rr.closed = true
+	rr.cancel()
+}
@@ -0,0 +1,159 @@
+package grpcx
+
+import (
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/oligarch316/go-netx/addressx"
+	"github.com/oligarch316/go-netx/registryx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/resolver"
+)
+
+func TestRegistryFormatEntry(t *testing.T) {
+	subtests := []struct {
+		name     string
+		entry    registryx.Entry
+		expected string
+	}{
+		{
+			name:     "tcp",
+			entry:    registryx.Entry{Network: "tcp", Address: "127.0.0.1:8080"},
+			expected: "127.0.0.1:8080",
+		},
+		{
+			name:     "unix absolute",
+			entry:    registryx.Entry{Network: "unix", Address: "/run/app.sock"},
+			expected: "unix:///run/app.sock",
+		},
+		{
+			name:     "unix relative",
+			entry:    registryx.Entry{Network: "unix", Address: "app.sock"},
+			expected: "unix:app.sock",
+		},
+		{
+			name:     "unix abstract",
+			entry:    registryx.Entry{Network: "unix", Address: "@app"},
+			expected: "unix-abstract:app",
+		},
+	}
+
+	for _, item := range subtests {
+		subtest := item
+
+		t.Run(subtest.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual := rsvFormatEntry(subtest.entry)
+			assert.Equal(t, subtest.expected, actual)
+
+			network, address := dialParseAddr(actual)
+			assert.Equal(t, subtest.entry.Network, network)
+			assert.Equal(t, subtest.entry.Address, address)
+		})
+	}
+}
+
+func newTestRegistryBuilder(t *testing.T) (*rsvBuilderRegistry, *registryx.File) {
+	t.Helper()
+
+	file := registryx.NewFile(
+		filepath.Join(t.TempDir(), "registry.json"),
+		registryx.WithPollInterval(time.Millisecond),
+	)
+
+	builder := &rsvBuilderRegistry{
+		params: RegistryParams{
+			File:       file,
+			SchemeName: "test-registry",
+			AddressOrdering: addressx.Ordering{
+				addressx.ByPriorityNetwork("unix", "tcp"),
+			},
+		},
+		policy: BalancerPriority,
+	}
+
+	return builder, file
+}
+
+func TestRegistryResolver(t *testing.T) {
+	var (
+		builder, file = newTestRegistryBuilder(t)
+		cc            = newMockClientConn()
+		target        = resolver.Target{URL: url.URL{Scheme: "test-registry", Path: "/A"}}
+
+		entryTCP  = registryx.Entry{Service: "A", Network: "tcp", Address: "127.0.0.1:1"}
+		entryUnix = registryx.Entry{Service: "A", Network: "unix", Address: "/run/a.sock"}
+		entryB    = registryx.Entry{Service: "B", Network: "tcp", Address: "127.0.0.1:2"}
+	)
+
+	rsv, err := builder.Build(target, cc, resolver.BuildOptions{})
+	require.NoError(t, err)
+	defer rsv.Close()
+
+	// Nothing published yet
+	assert.Error(t, <-cc.errs)
+
+	require.NoError(t, file.Publish("owner", entryTCP, entryUnix, entryB))
+
+	state := <-cc.states
+	require.Len(t, state.Addresses, 2)
+	assert.NotNil(t, state.ServiceConfig)
+
+	for i, expected := range []registryx.Entry{entryUnix, entryTCP} {
+		addr := state.Addresses[i]
+		assert.Equal(t, rsvFormatEntry(expected), addr.Addr)
+
+		network, ok := AddressNetwork(addr)
+		assert.True(t, ok)
+		assert.Equal(t, expected.Network, network)
+
+		priority, ok := AddressPriority(addr)
+		assert.True(t, ok)
+		assert.Equal(t, i, priority)
+	}
+}
+
+func TestRegistryResolverClose(t *testing.T) {
+	var (
+		builder, file = newTestRegistryBuilder(t)
+		cc            = newMockClientConn()
+		target        = resolver.Target{URL: url.URL{Scheme: "test-registry", Opaque: "A"}}
+		entry         = registryx.Entry{Service: "A", Network: "tcp", Address: "127.0.0.1:1"}
+	)
+
+	require.NoError(t, file.Publish("owner", entry))
+
+	rsv, err := builder.Build(target, cc, resolver.BuildOptions{})
+	require.NoError(t, err)
+
+	<-cc.states
+	rsv.Close()
+
+	// Neither a pending ResolveNow nor a direct update reaches the ClientConn
+	// once closed
+	rsv.ResolveNow(resolver.ResolveNowOptions{})
+	rsv.(*rsvRegistry).update(file.Load())
+	require.NoError(t, file.Publish("owner", entry, entry))
+
+	select {
+	case state := <-cc.states:
+		t.Fatalf("unexpected state update after close: %v", state)
+	case err := <-cc.errs:
+		t.Fatalf("unexpected error report after close: %s", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestRegistryResolverMissingService(t *testing.T) {
+	var (
+		builder, _ = newTestRegistryBuilder(t)
+		target     = resolver.Target{URL: url.URL{Scheme: "test-registry"}}
+	)
+
+	_, err := builder.Build(target, newMockClientConn(), resolver.BuildOptions{})
+	assert.Error(t, err)
+}
@@ -30,11 +30,17 @@ func rsvParseHash(s string) (local bool, h multi.SetHash, err error) {
 type hashResolver interface{ Resolve() []multi.SetAddr }
 
 // ResolverParams TODO.
-type ResolverParams struct{ SchemeName, DNSHostName *string }
+type ResolverParams struct {
+	SchemeName, DNSHostName *string
+
+	// BalancerPolicy defaults to BalancerPriority, an empty value leaves the
+	// choice to grpc (pick_first).
+	BalancerPolicy string
+}
 
 func (rp ResolverParams) build(hResolver hashResolver) []resolver.Builder {
 	var (
-		builder = rsvBuilder{hResolver: hResolver}
+		builder = rsvBuilder{hResolver: hResolver, policy: rp.BalancerPolicy}
 		res     []resolver.Builder
 	)
 
@@ -86,7 +92,10 @@ func (rbd *rsvBuilderDNS) Build(target resolver.Target, cc resolver.ClientConn,
 	return orig.Build(target, cc, opts)
 }
 
-type rsvBuilder struct{ hResolver hashResolver }
+type rsvBuilder struct {
+	hResolver hashResolver
+	policy    string
+}
 
 func (rb *rsvBuilder) build(cc resolver.ClientConn) (resolver.Resolver, error) {
 	hashAddrs := rb.hResolver.Resolve()
@@ -96,13 +105,33 @@ func (rb *rsvBuilder) build(cc resolver.ClientConn) (resolver.Resolver, error) {
 
 	rsvAddrs := make([]resolver.Address, len(hashAddrs))
 	for i, hashAddr := range hashAddrs {
-		rsvAddrs[i] = resolver.Address{Addr: rsvFormatHash(hashAddr)}
+		rsvAddr := resolver.Address{Addr: rsvFormatHash(hashAddr)}
+		rsvAddrs[i] = blrSetAttributes(rsvAddr, hashAddr.Network(), i)
+	}
+
+	state, err := rsvState(cc, rb.policy, rsvAddrs)
+	if err != nil {
+		return nil, err
 	}
 
-	cc.UpdateState(resolver.State{Addresses: rsvAddrs})
+	cc.UpdateState(state)
 	return &rsvNoop{}, nil
 }
 
+func rsvState(cc resolver.ClientConn, policy string, addrs []resolver.Address) (resolver.State, error) {
+	res := resolver.State{Addresses: addrs}
+
+	if policy != "" {
+		scResult := cc.ParseServiceConfig(blrServiceConfig(policy))
+		if scResult.Err != nil {
+			return res, fmt.Errorf("invalid balancer policy '%s': %w", policy, scResult.Err)
+		}
+		res.ServiceConfig = scResult
+	}
+
+	return res, nil
+}
+
 type rsvNoop struct{}
 
 func (*rsvNoop) ResolveNow(resolver.ResolveNowOptions) {}
@@ -3,10 +3,12 @@ package grpcx
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net"
 	"sync/atomic"
 
 	"github.com/oligarch316/go-netx"
+	"github.com/oligarch316/go-netx/tracex"
 	"google.golang.org/grpc"
 )
 
@@ -14,9 +16,16 @@ type namespace struct{}
 
 func (n namespace) String() string { return "grpcx" }
 
+type instance struct{ name string }
+
+func (i instance) String() string { return fmt.Sprintf("%s:%s", ID, i.name) }
+
 // ID TODO.
 var ID netx.ServiceID = namespace{}
 
+// NewID TODO.
+func NewID(name string) netx.ServiceID { return instance{name: name} }
+
 var errServiceClosed = errors.New("grpcx: service closed")
 
 // ServiceOption TODO.
@@ -24,12 +33,24 @@ type ServiceOption func(*ServiceParams)
 
 // ServiceParams TODO.
 type ServiceParams struct {
+	ID                netx.ServiceID
 	Handlers          []Handler
 	GRPCServerOptions []grpc.ServerOption
+
+	// Tracer, if set, traces every call handled by the service.
+	Tracer tracex.Tracer
 }
 
 func (sp ServiceParams) build() *grpc.Server {
-	res := grpc.NewServer(sp.GRPCServerOptions...)
+	opts := sp.GRPCServerOptions
+	if sp.Tracer != nil {
+		opts = append([]grpc.ServerOption{
+			grpc.ChainUnaryInterceptor(traceUnaryServerInterceptor(sp.Tracer)),
+			grpc.ChainStreamInterceptor(traceStreamServerInterceptor(sp.Tracer)),
+		}, opts...)
+	}
+
+	res := grpc.NewServer(opts...)
 	for _, h := range sp.Handlers {
 		h.Register(res)
 	}
@@ -47,21 +68,22 @@ func (hf HandlerFunc) Register(s *grpc.Server) { hf(s) }
 
 // Service TODO.
 type Service struct {
+	id        netx.ServiceID
 	svr       *grpc.Server
 	closeFlag uint32
 }
 
 // NewService TODO.
 func NewService(opts ...ServiceOption) *Service {
-	var params ServiceParams
+	params := ServiceParams{ID: ID}
 	for _, opt := range opts {
 		opt(&params)
 	}
-	return &Service{svr: params.build()}
+	return &Service{id: params.ID, svr: params.build()}
 }
 
 // ID TODO.
-func (s Service) ID() netx.ServiceID { return ID }
+func (s Service) ID() netx.ServiceID { return s.id }
 
 // Serve TODO.
 func (s *Service) Serve(l net.Listener) error {
@@ -0,0 +1,165 @@
+package grpcx_test
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/oligarch316/go-netx"
+	"github.com/oligarch316/go-netx/listenerx"
+	"github.com/oligarch316/go-netx/serverx"
+	"github.com/oligarch316/go-netx/servicex/grpcx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestNewID(t *testing.T) {
+	assert.Equal(t, "grpcx:public", grpcx.NewID("public").String())
+	assert.Equal(t, grpcx.NewID("public"), grpcx.NewID("public"))
+	assert.NotEqual(t, grpcx.NewID("public"), grpcx.NewID("internal"))
+	assert.NotEqual(t, grpcx.ID, grpcx.NewID(grpcx.ID.String()))
+}
+
+func TestServiceMultipleInstances(t *testing.T) {
+	var (
+		publicID   = grpcx.NewID("public")
+		internalID = grpcx.NewID("internal")
+	)
+
+	svr, err := serverx.NewServer(
+		grpcx.WithIDListeners(publicID, listenerx.NewInternal(0)),
+		grpcx.WithIDListeners(internalID, listenerx.NewInternal(0)),
+		grpcx.WithIDDependencies(publicID, internalID),
+	)
+	require.NoError(t, err)
+
+	newHealthService := func(id netx.ServiceID, status healthpb.HealthCheckResponse_ServingStatus) *grpcx.Service {
+		h := health.NewServer()
+		h.SetServingStatus("", status)
+
+		return grpcx.NewService(
+			grpcx.WithID(id),
+			grpcx.WithHandlerFuncs(func(s *grpc.Server) { healthpb.RegisterHealthServer(s, h) }),
+		)
+	}
+
+	var (
+		publicSvc   = newHealthService(publicID, healthpb.HealthCheckResponse_SERVING)
+		internalSvc = newHealthService(internalID, healthpb.HealthCheckResponse_NOT_SERVING)
+	)
+
+	assert.Equal(t, "grpcx:public", publicSvc.ID().String())
+
+	errs, err := svr.Serve(publicSvc, internalSvc)
+	require.NoError(t, err)
+
+	subtests := []struct {
+		name     string
+		id       netx.ServiceID
+		expected healthpb.HealthCheckResponse_ServingStatus
+	}{
+		{name: "public", id: publicID, expected: healthpb.HealthCheckResponse_SERVING},
+		{name: "internal", id: internalID, expected: healthpb.HealthCheckResponse_NOT_SERVING},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			dialer, err := grpcx.LoadDialer(svr, grpcx.WithDialerID(subtest.id))
+			require.NoError(t, err)
+
+			conn, err := dialer.Dial("localapp:///", grpc.WithTransportCredentials(insecure.NewCredentials()))
+			require.NoError(t, err)
+			defer conn.Close()
+
+			resp, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+			require.NoError(t, err)
+			assert.Equal(t, subtest.expected, resp.Status)
+		})
+	}
+
+	svr.Close(context.Background())
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+}
+
+func TestDialerUnix(t *testing.T) {
+	var (
+		dir      = t.TempDir()
+		abstract = fmt.Sprintf("go-netx-grpcx-test-%d", time.Now().UnixNano())
+	)
+
+	subtests := []struct {
+		name, path, target string
+		abstract           bool
+	}{
+		{
+			name:   "unix absolute",
+			path:   filepath.Join(dir, "absolute.sock"),
+			target: "unix://" + filepath.Join(dir, "absolute.sock"),
+		},
+		{
+			name:   "unix opaque",
+			path:   filepath.Join(dir, "opaque.sock"),
+			target: "unix:" + filepath.Join(dir, "opaque.sock"),
+		},
+		{
+			name:     "unix abstract",
+			path:     "@" + abstract,
+			target:   "unix-abstract:" + abstract,
+			abstract: true,
+		},
+	}
+
+	for _, item := range subtests {
+		subtest := item
+
+		t.Run(subtest.name, func(t *testing.T) {
+			t.Parallel()
+
+			if subtest.abstract && runtime.GOOS != "linux" {
+				t.Skip("abstract unix sockets only supported on linux")
+			}
+
+			l, err := listenerx.NewUnix(subtest.path)
+			require.NoError(t, err)
+
+			svr, err := serverx.NewServer(grpcx.WithListeners(l))
+			require.NoError(t, err)
+
+			h := health.NewServer()
+			svc := grpcx.NewService(grpcx.WithHandlerFuncs(func(s *grpc.Server) { healthpb.RegisterHealthServer(s, h) }))
+
+			errs, err := svr.Serve(svc)
+			require.NoError(t, err)
+
+			// Dialed through the custom dialer's fallback, not the server
+			dialer, err := grpcx.LoadDialer(svr)
+			require.NoError(t, err)
+
+			conn, err := dialer.Dial(subtest.target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+			require.NoError(t, err)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{}, grpc.WaitForReady(true))
+			require.NoError(t, err)
+			assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status)
+
+			conn.Close()
+
+			svr.Close(context.Background())
+			for err := range errs {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
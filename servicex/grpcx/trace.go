@@ -0,0 +1,149 @@
+package grpcx
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/oligarch316/go-netx/tracex"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func traceEnd(span tracex.Span, err error) {
+	span.SetAttributes(tracex.Attr("rpc.grpc.status_code", status.Code(err).String()))
+	if err != nil {
+		span.RecordError(err)
+	}
+	span.End()
+}
+
+// ----- Server
+
+func traceServerStart(ctx context.Context, tracer tracex.Tracer, method string) (context.Context, tracex.Span) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		ctx = tracex.Extract(ctx, tracex.MetadataCarrier(md))
+	}
+	return tracer.Start(ctx, "grpcx.service", tracex.Attr("rpc.method", method))
+}
+
+type traceServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (tss traceServerStream) Context() context.Context { return tss.ctx }
+
+func traceUnaryServerInterceptor(tracer tracex.Tracer) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, span := traceServerStart(ctx, tracer, info.FullMethod)
+
+		res, err := handler(ctx, req)
+		traceEnd(span, err)
+		return res, err
+	}
+}
+
+func traceStreamServerInterceptor(tracer tracex.Tracer) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, span := traceServerStart(ss.Context(), tracer, info.FullMethod)
+
+		err := handler(srv, traceServerStream{ServerStream: ss, ctx: ctx})
+		traceEnd(span, err)
+		return err
+	}
+}
+
+// ----- Client
+
+func traceClientStart(ctx context.Context, tracer tracex.Tracer, method string) (context.Context, tracex.Span) {
+	ctx, span := tracer.Start(ctx, "grpcx.dialer", tracex.Attr("rpc.method", method))
+
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+
+	tracex.Inject(ctx, tracex.MetadataCarrier(md))
+	return metadata.NewOutgoingContext(ctx, md), span
+}
+
+// traceClientStream ends its span once the stream has been fully consumed, has
+// failed or has been abandoned by canceling its context.
+type traceClientStream struct {
+	grpc.ClientStream
+	span          tracex.Span
+	serverStreams bool
+
+	endOnce  sync.Once
+	doneChan chan struct{}
+}
+
+func newTraceClientStream(ctx context.Context, cs grpc.ClientStream, desc *grpc.StreamDesc, span tracex.Span) *traceClientStream {
+	res := &traceClientStream{
+		ClientStream:  cs,
+		span:          span,
+		serverStreams: desc.ServerStreams,
+		doneChan:      make(chan struct{}),
+	}
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			res.end(status.FromContextError(ctx.Err()).Err())
+		case <-res.doneChan:
+		}
+	}()
+
+	return res
+}
+
+func (tcs *traceClientStream) end(err error) {
+	tcs.endOnce.Do(func() {
+		close(tcs.doneChan)
+		traceEnd(tcs.span, err)
+	})
+}
+
+func (tcs *traceClientStream) RecvMsg(m interface{}) error {
+	err := tcs.ClientStream.RecvMsg(m)
+	switch {
+	case errors.Is(err, io.EOF):
+		tcs.end(nil)
+	case err != nil:
+		tcs.end(err)
+	case !tcs.serverStreams:
+		// The single response of a unary or client streaming call, as received
+		// by CloseAndRecv, completes the stream
+		tcs.end(nil)
+	}
+	return err
+}
+
+func traceUnaryClientInterceptor(tracer tracex.Tracer) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, span := traceClientStart(ctx, tracer, method)
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		traceEnd(span, err)
+		return err
+	}
+}
+
+func traceStreamClientInterceptor(tracer tracex.Tracer) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx, span := traceClientStart(ctx, tracer, method)
+
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			traceEnd(span, err)
+			return nil, err
+		}
+
+		return newTraceClientStream(ctx, cs, desc, span), nil
+	}
+}
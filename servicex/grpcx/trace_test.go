@@ -0,0 +1,171 @@
+package grpcx_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/oligarch316/go-netx/listenerx"
+	"github.com/oligarch316/go-netx/serverx"
+	"github.com/oligarch316/go-netx/servicex/grpcx"
+	"github.com/oligarch316/go-netx/tracex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	testpb "google.golang.org/grpc/interop/grpc_testing"
+)
+
+func TestTracePropagation(t *testing.T) {
+	rec := tracex.NewRecorder()
+
+	svr, err := serverx.NewServer(grpcx.WithListeners(listenerx.NewInternal(0)))
+	require.NoError(t, err)
+
+	svc := grpcx.NewService(
+		grpcx.WithTracer(rec),
+		grpcx.WithHandlerFuncs(func(s *grpc.Server) { healthpb.RegisterHealthServer(s, health.NewServer()) }),
+	)
+
+	errs, err := svr.Serve(svc)
+	require.NoError(t, err)
+
+	dialer, err := grpcx.LoadDialer(svr, grpcx.WithDialerTracer(rec))
+	require.NoError(t, err)
+
+	conn, err := dialer.Dial("localapp:///", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+
+	ctx, root := rec.Start(context.Background(), "root")
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	root.End()
+
+	conn.Close()
+	svr.Close(context.Background())
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+
+	byName := make(map[string]tracex.RecordedSpan)
+	for _, span := range rec.Spans() {
+		byName[span.Name] = span
+	}
+
+	for name, parent := range map[string]string{
+		"grpcx.dialer":  "root",
+		"grpcx.service": "grpcx.dialer",
+	} {
+		require.Contains(t, byName, name)
+		assert.Equal(t, root.SpanContext().TraceID, byName[name].TraceID, name)
+		assert.Equal(t, byName[parent].SpanID, byName[name].ParentID, name)
+
+		method, _ := byName[name].Attribute("rpc.method")
+		assert.Equal(t, "/grpc.health.v1.Health/Check", method, name)
+	}
+}
+
+type testStreamServer struct {
+	testpb.UnimplementedTestServiceServer
+}
+
+func (testStreamServer) StreamingInputCall(stream testpb.TestService_StreamingInputCallServer) error {
+	var size int32
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return stream.SendAndClose(&testpb.StreamingInputCallResponse{AggregatedPayloadSize: size})
+		}
+		if err != nil {
+			return err
+		}
+		size += int32(len(req.GetPayload().GetBody()))
+	}
+}
+
+func (testStreamServer) FullDuplexCall(stream testpb.TestService_FullDuplexCallServer) error {
+	<-stream.Context().Done()
+	return stream.Context().Err()
+}
+
+func TestTraceClientStream(t *testing.T) {
+	rec := tracex.NewRecorder()
+
+	svr, err := serverx.NewServer(grpcx.WithListeners(listenerx.NewInternal(0)))
+	require.NoError(t, err)
+
+	svc := grpcx.NewService(
+		grpcx.WithHandlerFuncs(func(s *grpc.Server) { testpb.RegisterTestServiceServer(s, testStreamServer{}) }),
+	)
+
+	errs, err := svr.Serve(svc)
+	require.NoError(t, err)
+
+	dialer, err := grpcx.LoadDialer(svr, grpcx.WithDialerTracer(rec))
+	require.NoError(t, err)
+
+	conn, err := dialer.Dial("localapp:///", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+
+	client := testpb.NewTestServiceClient(conn)
+
+	span := func(method string) (tracex.RecordedSpan, bool) {
+		for _, item := range rec.Spans() {
+			if val, _ := item.Attribute("rpc.method"); val == method {
+				return item, true
+			}
+		}
+		return tracex.RecordedSpan{}, false
+	}
+
+	t.Run("close and recv", func(t *testing.T) {
+		stream, err := client.StreamingInputCall(context.Background())
+		require.NoError(t, err)
+
+		for _, body := range []string{"abc", "de"} {
+			require.NoError(t, stream.Send(&testpb.StreamingInputCallRequest{Payload: &testpb.Payload{Body: []byte(body)}}))
+		}
+
+		resp, err := stream.CloseAndRecv()
+		require.NoError(t, err)
+		assert.Equal(t, int32(5), resp.AggregatedPayloadSize)
+
+		recorded, ok := span("/grpc.testing.TestService/StreamingInputCall")
+		require.True(t, ok, "expected span ended by CloseAndRecv")
+
+		code, _ := recorded.Attribute("rpc.grpc.status_code")
+		assert.Equal(t, codes.OK.String(), code)
+	})
+
+	t.Run("abandoned", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		_, err := client.FullDuplexCall(ctx)
+		require.NoError(t, err)
+
+		// Neither consumed nor closed, only canceled
+		cancel()
+
+		var recorded tracex.RecordedSpan
+		require.Eventually(t, func() bool {
+			var ok bool
+			recorded, ok = span("/grpc.testing.TestService/FullDuplexCall")
+			return ok
+		}, time.Second, 10*time.Millisecond, "expected span ended by cancel")
+
+		code, _ := recorded.Attribute("rpc.grpc.status_code")
+		assert.Equal(t, codes.Canceled.String(), code)
+		assert.NotEmpty(t, recorded.Errors)
+	})
+
+	conn.Close()
+	svr.Close(context.Background())
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"net/http"
 
 	"github.com/oligarch316/go-netx"
+	"github.com/oligarch316/go-netx/limitx"
 	"github.com/oligarch316/go-netx/serverx"
+	"github.com/oligarch316/go-netx/tracex"
 )
 
 // ----- Server Options
@@ -19,6 +21,18 @@ func WithDependencies(deps ...netx.ServiceID) serverx.Option {
 	return serverx.WithDependencies(ID, deps...)
 }
 
+// WithIDListeners is WithListeners for the service given WithID, with an id
+// made by NewID.
+func WithIDListeners(id netx.ServiceID, ls ...netx.Listener) serverx.Option {
+	return serverx.WithListeners(id, ls...)
+}
+
+// WithIDDependencies is WithDependencies for the service given WithID, with an
+// id made by NewID.
+func WithIDDependencies(id netx.ServiceID, deps ...netx.ServiceID) serverx.Option {
+	return serverx.WithDependencies(id, deps...)
+}
+
 // ----- Service Options
 
 // MuxHandler TODO.
@@ -30,6 +44,25 @@ type MuxHandlerFunc func(*http.ServeMux)
 // Register TODO.
 func (mhf MuxHandlerFunc) Register(mux *http.ServeMux) { mhf(mux) }
 
+// WithID TODO.
+func WithID(id netx.ServiceID) ServiceOption {
+	return func(p *ServiceParams) { p.ID = id }
+}
+
+// WithTracer TODO.
+func WithTracer(tracer tracex.Tracer) ServiceOption {
+	return func(p *ServiceParams) { p.Tracer = tracer }
+}
+
+// WithRateLimit TODO.
+func WithRateLimit(rate limitx.Rate, opts ...RateLimitOption) ServiceOption {
+	return func(p *ServiceParams) {
+		p.RateLimits = append(p.RateLimits, func(next http.Handler) http.Handler {
+			return RateLimitHandler(next, rate, opts...)
+		})
+	}
+}
+
 // WithHTTPServerOptions TODO.
 func WithHTTPServerOptions(opts ...func(*http.Server)) ServiceOption {
 	return func(p *ServiceParams) { p.HTTPServerOptions = append(p.HTTPServerOptions, opts...) }
@@ -53,8 +86,35 @@ func WithMuxHandlerFuncs(fs ...func(*http.ServeMux)) ServiceOption {
 	return WithMuxHandlers(mhs...)
 }
 
+// ----- Rate Limit Options
+
+// WithRateLimitKey TODO.
+func WithRateLimitKey(key RateLimitKeyFunc) RateLimitOption {
+	return func(p *RateLimitParams) { p.Key = key }
+}
+
+// WithRateLimitMaxKeys TODO.
+func WithRateLimitMaxKeys(n int) RateLimitOption {
+	return func(p *RateLimitParams) { p.MaxKeys = n }
+}
+
+// WithRateLimitEventHandler TODO.
+func WithRateLimitEventHandler(handler func(RateLimitEvent)) RateLimitOption {
+	return func(p *RateLimitParams) { p.EventHandler = handler }
+}
+
 // ----- Transport Options
 
+// WithTransportID TODO.
+func WithTransportID(id netx.ServiceID) TransportOption {
+	return func(p *TransportParams) { p.ServiceID = id }
+}
+
+// WithTransportTracer TODO.
+func WithTransportTracer(tracer tracex.Tracer) TransportOption {
+	return func(p *TransportParams) { p.Tracer = tracer }
+}
+
 // WithHTTPTransportOptions TODO.
 func WithHTTPTransportOptions(opts ...func(*http.Transport)) TransportOption {
 	return func(p *TransportParams) { p.HTTPTransportOptions = append(p.HTTPTransportOptions, opts...) }
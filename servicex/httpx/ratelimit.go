@@ -0,0 +1,85 @@
+package httpx
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/oligarch316/go-netx/limitx"
+)
+
+// RateLimitKeyFunc TODO.
+type RateLimitKeyFunc func(*http.Request) string
+
+// RateLimitKeyRemoteIP keys requests by the host portion of their remote
+// address.
+func RateLimitKeyRemoteIP(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
+
+// RateLimitKeyHeader keys requests by the named header. The header is set by
+// the client, so unless a trusted proxy sets it each client may pick as many
+// keys as it likes: use only behind such a proxy, and see MaxKeys.
+func RateLimitKeyHeader(name string) RateLimitKeyFunc {
+	return func(r *http.Request) string { return r.Header.Get(name) }
+}
+
+// RateLimitEvent TODO.
+type RateLimitEvent struct {
+	Key        string
+	Request    *http.Request
+	RetryAfter time.Duration
+}
+
+// RateLimitOption TODO.
+type RateLimitOption func(*RateLimitParams)
+
+// RateLimitParams TODO.
+type RateLimitParams struct {
+	Rate         limitx.Rate
+	Key          RateLimitKeyFunc
+	EventHandler func(RateLimitEvent)
+
+	// MaxKeys caps the keys limited independently, beyond which new keys share
+	// a single limit, zero or less for no cap.
+	MaxKeys int
+}
+
+func defaultRateLimitParams(rate limitx.Rate) RateLimitParams {
+	return RateLimitParams{
+		Rate:         rate,
+		Key:          RateLimitKeyRemoteIP,
+		EventHandler: func(RateLimitEvent) {},
+		MaxKeys:      limitx.DefaultMaxKeys,
+	}
+}
+
+// RateLimitHandler sheds requests exceeding rate per key with a 429 status.
+func RateLimitHandler(next http.Handler, rate limitx.Rate, opts ...RateLimitOption) http.Handler {
+	params := defaultRateLimitParams(rate)
+	for _, opt := range opts {
+		opt(&params)
+	}
+
+	limiter := limitx.NewKeyed(params.Rate, params.MaxKeys)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := params.Key(r)
+
+		ok, wait := limiter.Allow(key)
+		if ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		params.EventHandler(RateLimitEvent{Key: key, Request: r, RetryAfter: wait})
+
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+		http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+	})
+}
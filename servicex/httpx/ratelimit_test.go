@@ -0,0 +1,56 @@
+package httpx_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/oligarch316/go-netx/limitx"
+	"github.com/oligarch316/go-netx/servicex/httpx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitHandler(t *testing.T) {
+	var (
+		events []httpx.RateLimitEvent
+		next   = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	)
+
+	handler := httpx.RateLimitHandler(
+		next,
+		limitx.Rate{Limit: 1, Burst: 1},
+		httpx.WithRateLimitKey(httpx.RateLimitKeyHeader("X-Client")),
+		httpx.WithRateLimitEventHandler(func(e httpx.RateLimitEvent) { events = append(events, e) }),
+	)
+
+	serve := func(client string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-Client", client)
+
+		res := httptest.NewRecorder()
+		handler.ServeHTTP(res, req)
+		return res
+	}
+
+	assert.Equal(t, http.StatusOK, serve("a").Code)
+	assert.Equal(t, http.StatusOK, serve("b").Code, "expected independent keys")
+
+	shed := serve("a")
+	assert.Equal(t, http.StatusTooManyRequests, shed.Code)
+	assert.Equal(t, "1", shed.Header().Get("Retry-After"))
+
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, "a", events[0].Key)
+		assert.Greater(t, int64(events[0].RetryAfter), int64(0))
+	}
+}
+
+func TestRateLimitKeyRemoteIP(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	req.RemoteAddr = "10.0.0.1:1234"
+	assert.Equal(t, "10.0.0.1", httpx.RateLimitKeyRemoteIP(req))
+
+	req.RemoteAddr = "internal-1"
+	assert.Equal(t, "internal-1", httpx.RateLimitKeyRemoteIP(req))
+}
@@ -3,20 +3,29 @@ package httpx
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net"
 	"net/http"
 	"sync/atomic"
 
 	"github.com/oligarch316/go-netx"
+	"github.com/oligarch316/go-netx/tracex"
 )
 
 type namespace struct{}
 
 func (n namespace) String() string { return "httpx" }
 
+type instance struct{ name string }
+
+func (i instance) String() string { return fmt.Sprintf("%s:%s", ID, i.name) }
+
 // ID TODO.
 var ID netx.ServiceID = namespace{}
 
+// NewID TODO.
+func NewID(name string) netx.ServiceID { return instance{name: name} }
+
 var errServiceClosed = errors.New("httpx: service closed")
 
 // ServiceOption TODO.
@@ -26,7 +35,15 @@ type ServiceOption func(*ServiceParams)
 type ServiceParams struct {
 	// TODO: observ/logging/event handling injection
 
+	ID                netx.ServiceID
 	HTTPServerOptions []func(*http.Server)
+
+	// Tracer, if set, traces every request handled by the service.
+	Tracer tracex.Tracer
+
+	// RateLimits are applied in order to every request handled by the
+	// service, within any tracing.
+	RateLimits []func(http.Handler) http.Handler
 }
 
 func (sp ServiceParams) build() *http.Server {
@@ -34,26 +51,47 @@ func (sp ServiceParams) build() *http.Server {
 	for _, opt := range sp.HTTPServerOptions {
 		opt(res)
 	}
+
+	if len(sp.RateLimits) < 1 && sp.Tracer == nil {
+		return res
+	}
+
+	handler := res.Handler
+	if handler == nil {
+		handler = http.DefaultServeMux
+	}
+
+	for i := len(sp.RateLimits) - 1; i >= 0; i-- {
+		handler = sp.RateLimits[i](handler)
+	}
+
+	if sp.Tracer != nil {
+		handler = traceHandler(sp.Tracer, handler)
+	}
+
+	res.Handler = handler
+
 	return res
 }
 
 // Service TODO.
 type Service struct {
+	id        netx.ServiceID
 	svr       *http.Server
 	closeFlag uint32
 }
 
 // NewService TODO.
 func NewService(opts ...ServiceOption) *Service {
-	var params ServiceParams
+	params := ServiceParams{ID: ID}
 	for _, opt := range opts {
 		opt(&params)
 	}
-	return &Service{svr: params.build()}
+	return &Service{id: params.ID, svr: params.build()}
 }
 
 // ID TODO.
-func (Service) ID() netx.ServiceID { return ID }
+func (s Service) ID() netx.ServiceID { return s.id }
 
 // Serve TODO.
 func (s *Service) Serve(l net.Listener) error {
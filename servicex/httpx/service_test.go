@@ -0,0 +1,96 @@
+package httpx_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/oligarch316/go-netx"
+	"github.com/oligarch316/go-netx/listenerx"
+	"github.com/oligarch316/go-netx/serverx"
+	"github.com/oligarch316/go-netx/servicex/httpx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewID(t *testing.T) {
+	assert.Equal(t, "httpx:public", httpx.NewID("public").String())
+	assert.Equal(t, httpx.NewID("public"), httpx.NewID("public"))
+	assert.NotEqual(t, httpx.NewID("public"), httpx.NewID("internal"))
+	assert.NotEqual(t, httpx.ID, httpx.NewID(httpx.ID.String()))
+}
+
+func TestServiceMultipleInstances(t *testing.T) {
+	var (
+		publicID   = httpx.NewID("public")
+		internalID = httpx.NewID("internal")
+	)
+
+	svr, err := serverx.NewServer(
+		httpx.WithIDListeners(publicID, listenerx.NewInternal(0)),
+		httpx.WithIDListeners(internalID, listenerx.NewInternal(0)),
+		httpx.WithIDDependencies(publicID, internalID),
+	)
+	require.NoError(t, err)
+
+	newService := func(id netx.ServiceID) *httpx.Service {
+		return httpx.NewService(
+			httpx.WithID(id),
+			httpx.WithHTTPServerOptions(func(s *http.Server) {
+				s.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					io.WriteString(w, id.String())
+				})
+			}),
+		)
+	}
+
+	var (
+		publicSvc   = newService(publicID)
+		internalSvc = newService(internalID)
+	)
+
+	assert.Equal(t, publicID, publicSvc.ID())
+	assert.Equal(t, internalID, internalSvc.ID())
+
+	errs, err := svr.Serve(publicSvc, internalSvc)
+	require.NoError(t, err)
+
+	deps, err := svr.Dependencies(publicID)
+	require.NoError(t, err)
+	assert.Equal(t, []netx.ServiceID{internalID}, deps)
+
+	subtests := []struct {
+		name string
+		id   netx.ServiceID
+	}{
+		{name: "public", id: publicID},
+		{name: "internal", id: internalID},
+	}
+
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			transport, err := httpx.LoadTransport(svr, httpx.WithTransportID(subtest.id))
+			require.NoError(t, err)
+			defer transport.CloseIdleConnections()
+
+			resp, err := (&http.Client{Transport: transport}).Get("localapp:///")
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, subtest.id.String(), string(body))
+		})
+	}
+
+	t.Run("default id", func(t *testing.T) {
+		_, err := httpx.LoadTransport(svr)
+		assert.Error(t, err, "expected no service under the default id")
+	})
+
+	svr.Close(context.Background())
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+}
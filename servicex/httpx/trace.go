@@ -0,0 +1,57 @@
+package httpx
+
+import (
+	"net/http"
+
+	"github.com/oligarch316/go-netx/internal/httpwriter"
+	"github.com/oligarch316/go-netx/tracex"
+)
+
+func traceHandler(tracer tracex.Tracer, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := tracex.Extract(r.Context(), tracex.HeaderCarrier(r.Header))
+
+		ctx, span := tracer.Start(
+			ctx, "httpx.service",
+			tracex.Attr("http.method", r.Method),
+			tracex.Attr("http.target", r.URL.RequestURI()),
+		)
+		defer span.End()
+
+		rw := httpwriter.New(w)
+		next.ServeHTTP(rw, r.WithContext(ctx))
+
+		status := rw.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		span.SetAttributes(tracex.Attr("http.status_code", status))
+	})
+}
+
+type transportTrace struct {
+	Transport
+	tracer tracex.Tracer
+}
+
+func (tt *transportTrace) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := tt.tracer.Start(
+		req.Context(), "httpx.transport",
+		tracex.Attr("http.method", req.Method),
+		tracex.Attr("http.url", req.URL.String()),
+	)
+	defer span.End()
+
+	// RoundTrippers must not modify the request, so propagate via a clone
+	req = req.Clone(ctx)
+	tracex.Inject(ctx, tracex.HeaderCarrier(req.Header))
+
+	resp, err := tt.Transport.RoundTrip(req)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	span.SetAttributes(tracex.Attr("http.status_code", resp.StatusCode))
+	return resp, nil
+}
@@ -0,0 +1,118 @@
+package httpx_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/oligarch316/go-netx/listenerx"
+	"github.com/oligarch316/go-netx/listenerx/multi"
+	"github.com/oligarch316/go-netx/serverx"
+	"github.com/oligarch316/go-netx/servicex/httpx"
+	"github.com/oligarch316/go-netx/tracex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracePropagation(t *testing.T) {
+	rec := tracex.NewRecorder()
+
+	svr, err := serverx.NewServer(
+		httpx.WithListeners(listenerx.NewInternal(0)),
+		serverx.WithListenerOpts(httpx.ID, multi.WithTracer(rec)),
+	)
+	require.NoError(t, err)
+
+	svc := httpx.NewService(
+		httpx.WithTracer(rec),
+		httpx.WithHTTPServerOptions(func(s *http.Server) {
+			s.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, span := rec.Start(r.Context(), "handler")
+				span.End()
+				io.WriteString(w, "ok")
+			})
+		}),
+	)
+
+	errs, err := svr.Serve(svc)
+	require.NoError(t, err)
+
+	transport, err := httpx.LoadTransport(svr, httpx.WithTransportTracer(rec))
+	require.NoError(t, err)
+
+	ctx, root := rec.Start(context.Background(), "root")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "localapp:///path", nil)
+	require.NoError(t, err)
+
+	resp, err := (&http.Client{Transport: transport}).Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	root.End()
+
+	transport.CloseIdleConnections()
+	svr.Close(context.Background())
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+
+	byName := make(map[string]tracex.RecordedSpan)
+	for _, span := range rec.Spans() {
+		byName[span.Name] = span
+	}
+
+	// Accepted connections are not associated with any request
+	assert.Contains(t, byName, "multi.handoff")
+	assert.Empty(t, byName["multi.handoff"].ParentID)
+
+	for name, parent := range map[string]string{
+		"httpx.transport": "root",
+		"multi.dial":      "httpx.transport",
+		"httpx.service":   "httpx.transport",
+		"handler":         "httpx.service",
+	} {
+		require.Contains(t, byName, name)
+		assert.Equal(t, root.SpanContext().TraceID, byName[name].TraceID, name)
+		assert.Equal(t, byName[parent].SpanID, byName[name].ParentID, name)
+	}
+
+	status, _ := byName["httpx.service"].Attribute("http.status_code")
+	assert.Equal(t, http.StatusOK, status)
+}
+
+func TestTraceMixedTracers(t *testing.T) {
+	rec := tracex.NewRecorder()
+
+	// The listener, and so its dialer, is left with the default no-op tracer
+	svr, err := serverx.NewServer(httpx.WithListeners(listenerx.NewInternal(0)))
+	require.NoError(t, err)
+
+	errs, err := svr.Serve(httpx.NewService())
+	require.NoError(t, err)
+
+	transport, err := httpx.LoadTransport(svr, httpx.WithTransportTracer(rec))
+	require.NoError(t, err)
+
+	resp, err := (&http.Client{Transport: transport}).Get("localapp:///path")
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	transport.CloseIdleConnections()
+	svr.Close(context.Background())
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+
+	spans := rec.Spans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "httpx.transport", spans[0].Name)
+
+	// Not ended early by the dialer, nor given its attributes
+	status, ok := spans[0].Attribute("http.status_code")
+	assert.True(t, ok, "expected status recorded before the span ended")
+	assert.Equal(t, http.StatusNotFound, status)
+
+	_, ok = spans[0].Attribute("net.peer.addr")
+	assert.False(t, ok, "expected no dialer attributes")
+}
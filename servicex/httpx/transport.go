@@ -7,6 +7,7 @@ import (
 	"github.com/oligarch316/go-netx"
 	"github.com/oligarch316/go-netx/serverx"
 	"github.com/oligarch316/go-netx/servicex"
+	"github.com/oligarch316/go-netx/tracex"
 )
 
 const (
@@ -19,14 +20,22 @@ type TransportOption func(*TransportParams)
 
 // TransportParams TODO.
 type TransportParams struct {
+	// ServiceID selects the service dialed by LoadTransport.
+	ServiceID netx.ServiceID
+
 	HostName, SchemeName, SchemeTLSName *string
 	HTTPTransportOptions                []func(*http.Transport)
+
+	// Tracer, if set, traces every round trip and propagates the span context
+	// to the service via request headers.
+	Tracer tracex.Tracer
 }
 
 func defaultTransportParams() TransportParams {
 	schemeName := servicex.DefaultDialKey
 
 	return TransportParams{
+		ServiceID:            ID,
 		HostName:             nil,
 		SchemeTLSName:        nil,
 		SchemeName:           &schemeName,
@@ -51,7 +60,12 @@ type Transport interface {
 
 // LoadTransport TODO.
 func LoadTransport(svr *serverx.Server, opts ...TransportOption) (Transport, error) {
-	dialer, err := svr.Dialer(ID)
+	params := defaultTransportParams()
+	for _, opt := range opts {
+		opt(&params)
+	}
+
+	dialer, err := svr.Dialer(params.ServiceID)
 	if err != nil {
 		return nil, err
 	}
@@ -110,6 +124,10 @@ func NewTransport(dialer netx.Dialer, opts ...TransportOption) Transport {
 		})
 	}
 
+	if params.Tracer != nil {
+		res = &transportTrace{Transport: res, tracer: params.Tracer}
+	}
+
 	return res
 }
 
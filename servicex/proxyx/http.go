@@ -0,0 +1,155 @@
+package proxyx
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+
+	"github.com/oligarch316/go-netx"
+	"github.com/oligarch316/go-netx/internal/httpwriter"
+	"github.com/oligarch316/go-netx/servicex/httpx"
+)
+
+type httpNamespace struct{}
+
+func (n httpNamespace) String() string { return "proxyx-http" }
+
+type httpInstance struct{ name string }
+
+func (hi httpInstance) String() string { return fmt.Sprintf("%s:%s", HTTPID, hi.name) }
+
+// HTTPID TODO.
+var HTTPID netx.ServiceID = httpNamespace{}
+
+// NewHTTPID TODO.
+func NewHTTPID(name string) netx.ServiceID { return httpInstance{name: name} }
+
+const httpTargetHost = "_proxyx_target_"
+
+// HTTPServiceOption TODO.
+type HTTPServiceOption func(*HTTPServiceParams)
+
+// HTTPServiceParams TODO.
+type HTTPServiceParams struct {
+	ID                  netx.ServiceID
+	SessionHandler      SessionHandler
+	ReverseProxyOptions []func(*httputil.ReverseProxy)
+	ServiceOptions      []httpx.ServiceOption
+	TransportOptions    []httpx.TransportOption
+}
+
+// HTTPService TODO.
+type HTTPService struct {
+	*httpx.Service
+
+	id       netx.ServiceID
+	sessions *sessionTracker
+	proxy    *httputil.ReverseProxy
+}
+
+// NewHTTPService TODO.
+func NewHTTPService(target netx.Dialer, opts ...HTTPServiceOption) *HTTPService {
+	params := HTTPServiceParams{ID: HTTPID}
+	for _, opt := range opts {
+		opt(&params)
+	}
+
+	transportOpts := append(
+		[]httpx.TransportOption{httpx.WithResolveNoScheme},
+		params.TransportOptions...,
+	)
+
+	// Applied last so the target host cannot be overridden
+	transportOpts = append(transportOpts, httpx.WithResolveHostName(httpTargetHost))
+
+	res := &HTTPService{
+		id:       params.ID,
+		sessions: newSessionTracker(params.SessionHandler),
+		proxy: &httputil.ReverseProxy{
+			Director:  httpDirector,
+			Transport: httpx.NewTransport(target, transportOpts...),
+		},
+	}
+
+	for _, opt := range params.ReverseProxyOptions {
+		opt(res.proxy)
+	}
+
+	res.proxy.ErrorHandler = httpErrorHandler(res.proxy.ErrorHandler)
+
+	svcOpts := append(params.ServiceOptions, httpx.WithHTTPServerOptions(func(s *http.Server) { s.Handler = res }))
+	res.Service = httpx.NewService(svcOpts...)
+
+	return res
+}
+
+func httpDirector(r *http.Request) {
+	r.URL.Scheme, r.URL.Host = "http", httpTargetHost
+
+	if _, ok := r.Header["User-Agent"]; !ok {
+		// Prevent the transport from setting a default user agent
+		r.Header.Set("User-Agent", "")
+	}
+}
+
+// ID TODO.
+func (hs *HTTPService) ID() netx.ServiceID { return hs.id }
+
+// Sessions TODO.
+func (hs *HTTPService) Sessions() []Session { return hs.sessions.sessions() }
+
+// ServeHTTP TODO.
+func (hs *HTTPService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		rw   = httpwriter.New(w)
+		sess = hs.sessions.start(r.Host, r.RemoteAddr, rw.Written)
+	)
+
+	if r.Body != nil && r.Body != http.NoBody {
+		r.Body = &httpCountBody{ReadCloser: r.Body, counter: sessionCounter{&sess.bytesIn}}
+	}
+
+	r = r.WithContext(context.WithValue(r.Context(), httpSessionKey{}, sess))
+
+	hs.proxy.ServeHTTP(rw, r)
+
+	err := sess.proxyErr
+	if err == nil {
+		err = r.Context().Err()
+	}
+
+	hs.sessions.end(sess, err)
+}
+
+type httpSessionKey struct{}
+
+// httpErrorHandler records proxy errors, such as failed dials of the target,
+// in the session of the request before passing them on to next. Without next,
+// the client receives a bad gateway response as from a default ReverseProxy.
+func httpErrorHandler(next func(http.ResponseWriter, *http.Request, error)) func(http.ResponseWriter, *http.Request, error) {
+	return func(w http.ResponseWriter, r *http.Request, err error) {
+		if sess, ok := r.Context().Value(httpSessionKey{}).(*session); ok {
+			sess.proxyErr = err
+		}
+
+		if next != nil {
+			next(w, r, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusBadGateway)
+	}
+}
+
+type httpCountBody struct {
+	io.ReadCloser
+	counter sessionCounter
+}
+
+func (hcb *httpCountBody) Read(p []byte) (int, error) {
+	n, err := hcb.ReadCloser.Read(p)
+	hcb.counter.add(n)
+	return n, err
+}
@@ -0,0 +1,89 @@
+package proxyx
+
+import (
+	"net/http/httputil"
+
+	"github.com/oligarch316/go-netx"
+	"github.com/oligarch316/go-netx/serverx"
+	"github.com/oligarch316/go-netx/servicex/httpx"
+	"github.com/oligarch316/go-netx/servicex/streamx"
+)
+
+// ----- Server Options
+
+// WithListeners TODO.
+func WithListeners(ls ...netx.Listener) serverx.Option {
+	return serverx.WithListeners(ID, ls...)
+}
+
+// WithDependencies TODO.
+func WithDependencies(deps ...netx.ServiceID) serverx.Option {
+	return serverx.WithDependencies(ID, deps...)
+}
+
+// WithHTTPListeners TODO.
+func WithHTTPListeners(ls ...netx.Listener) serverx.Option {
+	return serverx.WithListeners(HTTPID, ls...)
+}
+
+// WithHTTPDependencies TODO.
+func WithHTTPDependencies(deps ...netx.ServiceID) serverx.Option {
+	return serverx.WithDependencies(HTTPID, deps...)
+}
+
+// WithIDListeners is WithListeners or WithHTTPListeners for the service given
+// WithID or WithHTTPID, with an id made by NewID or NewHTTPID respectively.
+func WithIDListeners(id netx.ServiceID, ls ...netx.Listener) serverx.Option {
+	return serverx.WithListeners(id, ls...)
+}
+
+// WithIDDependencies is WithDependencies or WithHTTPDependencies for the
+// service given WithID or WithHTTPID, with an id made by NewID or NewHTTPID
+// respectively.
+func WithIDDependencies(id netx.ServiceID, deps ...netx.ServiceID) serverx.Option {
+	return serverx.WithDependencies(id, deps...)
+}
+
+// ----- Service Options
+
+// WithID TODO.
+func WithID(id netx.ServiceID) ServiceOption {
+	return func(p *ServiceParams) { p.ID = id }
+}
+
+// WithSessionHandler TODO.
+func WithSessionHandler(handler SessionHandler) ServiceOption {
+	return func(p *ServiceParams) { p.SessionHandler = handler }
+}
+
+// WithStreamOptions TODO.
+func WithStreamOptions(opts ...streamx.ServiceOption) ServiceOption {
+	return func(p *ServiceParams) { p.StreamOptions = append(p.StreamOptions, opts...) }
+}
+
+// ----- HTTP Service Options
+
+// WithHTTPID TODO.
+func WithHTTPID(id netx.ServiceID) HTTPServiceOption {
+	return func(p *HTTPServiceParams) { p.ID = id }
+}
+
+// WithHTTPSessionHandler TODO.
+func WithHTTPSessionHandler(handler SessionHandler) HTTPServiceOption {
+	return func(p *HTTPServiceParams) { p.SessionHandler = handler }
+}
+
+// WithReverseProxyOptions TODO.
+func WithReverseProxyOptions(opts ...func(*httputil.ReverseProxy)) HTTPServiceOption {
+	return func(p *HTTPServiceParams) { p.ReverseProxyOptions = append(p.ReverseProxyOptions, opts...) }
+}
+
+// WithHTTPServiceOptions TODO.
+func WithHTTPServiceOptions(opts ...httpx.ServiceOption) HTTPServiceOption {
+	return func(p *HTTPServiceParams) { p.ServiceOptions = append(p.ServiceOptions, opts...) }
+}
+
+// WithHTTPTransportOptions TODO.
+func WithHTTPTransportOptions(opts ...httpx.TransportOption) HTTPServiceOption {
+	return func(p *HTTPServiceParams) { p.TransportOptions = append(p.TransportOptions, opts...) }
+}
@@ -0,0 +1,420 @@
+package proxyx_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/oligarch316/go-netx"
+	"github.com/oligarch316/go-netx/listenerx"
+	"github.com/oligarch316/go-netx/listenerx/multi"
+	"github.com/oligarch316/go-netx/serverx"
+	"github.com/oligarch316/go-netx/servicex/httpx"
+	"github.com/oligarch316/go-netx/servicex/proxyx"
+	"github.com/oligarch316/go-netx/servicex/streamx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceTCP(t *testing.T) {
+	svr, err := serverx.NewServer(
+		streamx.WithListeners(listenerx.NewInternal(0)),
+		proxyx.WithListeners(listenerx.NewInternal(0)),
+		proxyx.WithDependencies(streamx.ID),
+	)
+	require.NoError(t, err)
+
+	target, err := svr.Dialer(streamx.ID)
+	require.NoError(t, err)
+
+	var (
+		sessions = make(chan proxyx.Session, 1)
+		echoSvc  = streamx.NewService(func(_ context.Context, conn net.Conn) { io.Copy(conn, conn) })
+		proxySvc = proxyx.NewService(target, proxyx.WithSessionHandler(func(s proxyx.Session) { sessions <- s }))
+	)
+
+	errs, err := svr.Serve(echoSvc, proxySvc)
+	require.NoError(t, err)
+
+	dialer, err := svr.Dialer(proxyx.ID)
+	require.NoError(t, err)
+
+	conn, err := dialer.Dial()
+	require.NoError(t, err)
+
+	_, err = conn.Write([]byte("hello"))
+	require.NoError(t, err)
+
+	buf := make([]byte, 5)
+	_, err = io.ReadFull(conn, buf)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(buf))
+
+	active := proxySvc.Sessions()
+	require.Len(t, active, 1)
+	assert.Equal(t, int64(5), active[0].BytesIn)
+
+	require.NoError(t, conn.Close())
+
+	session := <-sessions
+	assert.NoError(t, session.Err)
+	assert.Equal(t, int64(5), session.BytesIn)
+	assert.Equal(t, int64(5), session.BytesOut)
+	assert.Empty(t, proxySvc.Sessions())
+
+	svr.Close(context.Background())
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+}
+
+func TestServiceTCPHalfClose(t *testing.T) {
+	svr, err := serverx.NewServer(
+		streamx.WithListeners(listenerx.NewInternal(0)),
+		proxyx.WithListeners(listenerx.NewInternal(0)),
+		proxyx.WithDependencies(streamx.ID),
+	)
+	require.NoError(t, err)
+
+	target, err := svr.Dialer(streamx.ID)
+	require.NoError(t, err)
+
+	var (
+		// Responds only once the request is complete
+		upperSvc = streamx.NewService(func(_ context.Context, conn net.Conn) {
+			req, _ := io.ReadAll(conn)
+			conn.Write([]byte(strings.ToUpper(string(req))))
+		})
+		proxySvc = proxyx.NewService(target)
+	)
+
+	errs, err := svr.Serve(upperSvc, proxySvc)
+	require.NoError(t, err)
+
+	dialer, err := svr.Dialer(proxyx.ID)
+	require.NoError(t, err)
+
+	conn, err := dialer.Dial()
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = conn.Write([]byte("hello"))
+	require.NoError(t, err)
+
+	cw, ok := conn.(interface{ CloseWrite() error })
+	require.True(t, ok, "expected half close support")
+	require.NoError(t, cw.CloseWrite())
+
+	// The response in flight survives the client's half close
+	resp, err := io.ReadAll(conn)
+	require.NoError(t, err)
+	assert.Equal(t, "HELLO", string(resp))
+
+	svr.Close(context.Background())
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+}
+
+func TestServiceTCPTargetHalfClose(t *testing.T) {
+	subtests := []struct {
+		name string
+		opts []serverx.Option
+	}{
+		{name: "untracked"},
+		{
+			name: "tracked",
+			opts: []serverx.Option{serverx.WithDefaultListenerOpts(multi.WithRunnerConnTracking(true))},
+		},
+	}
+
+	for _, item := range subtests {
+		subtest := item
+
+		t.Run(subtest.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := append([]serverx.Option{
+				streamx.WithListeners(listenerx.NewInternal(0)),
+				proxyx.WithListeners(listenerx.NewInternal(0)),
+				proxyx.WithDependencies(streamx.ID),
+			}, subtest.opts...)
+
+			svr, err := serverx.NewServer(opts...)
+			require.NoError(t, err)
+
+			target, err := svr.Dialer(streamx.ID)
+			require.NoError(t, err)
+
+			var (
+				// Responds and closes without reading a request
+				helloSvc = streamx.NewService(func(_ context.Context, conn net.Conn) {
+					conn.Write([]byte("hello"))
+				})
+				proxySvc = proxyx.NewService(target)
+			)
+
+			errs, err := svr.Serve(helloSvc, proxySvc)
+			require.NoError(t, err)
+
+			dialer, err := svr.Dialer(proxyx.ID)
+			require.NoError(t, err)
+
+			conn, err := dialer.Dial()
+			require.NoError(t, err)
+
+			// The target's EOF reaches the client as a half close, even
+			// through the accepted connection's wrapper
+			require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+
+			resp, err := io.ReadAll(conn)
+			require.NoError(t, err)
+			assert.Equal(t, "hello", string(resp))
+
+			conn.Close()
+
+			svr.Close(context.Background())
+			for err := range errs {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// plainDialer hides any half close support of the conns it dials.
+type plainDialer struct{ netx.Dialer }
+
+func (pd plainDialer) Dial() (net.Conn, error) { return pd.DialContext(context.Background()) }
+
+func (pd plainDialer) DialContext(ctx context.Context) (net.Conn, error) {
+	conn, err := pd.Dialer.DialContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return struct{ net.Conn }{conn}, nil
+}
+
+func TestServiceTCPHalfCloseUnsupported(t *testing.T) {
+	svr, err := serverx.NewServer(
+		streamx.WithListeners(listenerx.NewInternal(0)),
+		proxyx.WithListeners(listenerx.NewInternal(0)),
+		proxyx.WithDependencies(streamx.ID),
+	)
+	require.NoError(t, err)
+
+	target, err := svr.Dialer(streamx.ID)
+	require.NoError(t, err)
+
+	var (
+		// Responds to a fixed size request, without waiting on EOF
+		echoSvc = streamx.NewService(func(_ context.Context, conn net.Conn) {
+			buf := make([]byte, 5)
+			if _, err := io.ReadFull(conn, buf); err == nil {
+				conn.Write(buf)
+			}
+		})
+		proxySvc = proxyx.NewService(plainDialer{target})
+	)
+
+	errs, err := svr.Serve(echoSvc, proxySvc)
+	require.NoError(t, err)
+
+	dialer, err := svr.Dialer(proxyx.ID)
+	require.NoError(t, err)
+
+	conn, err := dialer.Dial()
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = conn.Write([]byte("hello"))
+	require.NoError(t, err)
+
+	cw, ok := conn.(interface{ CloseWrite() error })
+	require.True(t, ok, "expected half close support")
+	require.NoError(t, cw.CloseWrite())
+
+	// Without half close toward the target, the target is left open rather
+	// than closed along with the response in flight
+	resp, err := io.ReadAll(conn)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(resp))
+
+	svr.Close(context.Background())
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+}
+
+func TestServiceHTTP(t *testing.T) {
+	svr, err := serverx.NewServer(
+		httpx.WithListeners(listenerx.NewInternal(0)),
+		proxyx.WithHTTPListeners(listenerx.NewInternal(0)),
+		proxyx.WithHTTPDependencies(httpx.ID),
+	)
+	require.NoError(t, err)
+
+	target, err := svr.Dialer(httpx.ID)
+	require.NoError(t, err)
+
+	var (
+		sessions = make(chan proxyx.Session, 1)
+		backend  = httpx.NewService(httpx.WithMuxHandlerFuncs(func(mux *http.ServeMux) {
+			mux.HandleFunc("/echo", func(w http.ResponseWriter, r *http.Request) { io.Copy(w, r.Body) })
+		}))
+		proxySvc = proxyx.NewHTTPService(target, proxyx.WithHTTPSessionHandler(func(s proxyx.Session) { sessions <- s }))
+	)
+
+	errs, err := svr.Serve(backend, proxySvc)
+	require.NoError(t, err)
+
+	dialer, err := svr.Dialer(proxyx.HTTPID)
+	require.NoError(t, err)
+
+	client := &http.Client{Transport: httpx.NewTransport(dialer)}
+
+	resp, err := client.Post("localapp:///echo", "text/plain", strings.NewReader("hello"))
+	require.NoError(t, err)
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(body))
+
+	session := <-sessions
+	assert.Equal(t, int64(5), session.BytesIn)
+	assert.Equal(t, int64(5), session.BytesOut)
+
+	client.CloseIdleConnections()
+	svr.Close(context.Background())
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+}
+
+func TestServiceHTTPProxyError(t *testing.T) {
+	// Dials of the closed target fail
+	target := listenerx.NewInternal(0)
+	require.NoError(t, target.Close())
+
+	svr, err := serverx.NewServer(proxyx.WithHTTPListeners(listenerx.NewInternal(0)))
+	require.NoError(t, err)
+
+	var (
+		sessions = make(chan proxyx.Session, 1)
+		proxySvc = proxyx.NewHTTPService(target, proxyx.WithHTTPSessionHandler(func(s proxyx.Session) { sessions <- s }))
+	)
+
+	errs, err := svr.Serve(proxySvc)
+	require.NoError(t, err)
+
+	dialer, err := svr.Dialer(proxyx.HTTPID)
+	require.NoError(t, err)
+
+	client := &http.Client{Transport: httpx.NewTransport(dialer)}
+
+	resp, err := client.Get("localapp:///")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+
+	session := <-sessions
+	assert.Error(t, session.Err)
+
+	client.CloseIdleConnections()
+	svr.Close(context.Background())
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+}
+
+func TestServiceMultipleInstances(t *testing.T) {
+	var (
+		tcpID  = proxyx.NewID("tcp")
+		httpID = proxyx.NewHTTPID("http")
+	)
+
+	assert.Equal(t, "proxyx:tcp", tcpID.String())
+	assert.Equal(t, "proxyx-http:http", httpID.String())
+	assert.NotEqual(t, proxyx.ID, proxyx.NewID(proxyx.ID.String()))
+	assert.NotEqual(t, proxyx.HTTPID, proxyx.NewHTTPID(proxyx.HTTPID.String()))
+
+	svr, err := serverx.NewServer(
+		streamx.WithListeners(listenerx.NewInternal(0)),
+		httpx.WithListeners(listenerx.NewInternal(0)),
+		proxyx.WithListeners(listenerx.NewInternal(0)),
+		proxyx.WithDependencies(streamx.ID),
+		proxyx.WithIDListeners(tcpID, listenerx.NewInternal(0)),
+		proxyx.WithIDDependencies(tcpID, streamx.ID),
+		proxyx.WithHTTPListeners(listenerx.NewInternal(0)),
+		proxyx.WithHTTPDependencies(httpx.ID),
+		proxyx.WithIDListeners(httpID, listenerx.NewInternal(0)),
+		proxyx.WithIDDependencies(httpID, httpx.ID),
+	)
+	require.NoError(t, err)
+
+	streamTarget, err := svr.Dialer(streamx.ID)
+	require.NoError(t, err)
+
+	httpTarget, err := svr.Dialer(httpx.ID)
+	require.NoError(t, err)
+
+	var (
+		helloSvc = streamx.NewService(func(_ context.Context, conn net.Conn) { io.WriteString(conn, "hello") })
+		backend  = httpx.NewService(httpx.WithHTTPServerOptions(func(s *http.Server) {
+			s.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { io.WriteString(w, "hello") })
+		}))
+
+		tcpSvcs = []*proxyx.Service{
+			proxyx.NewService(streamTarget),
+			proxyx.NewService(streamTarget, proxyx.WithID(tcpID)),
+		}
+		httpSvcs = []*proxyx.HTTPService{
+			proxyx.NewHTTPService(httpTarget),
+			proxyx.NewHTTPService(httpTarget, proxyx.WithHTTPID(httpID)),
+		}
+	)
+
+	assert.Equal(t, proxyx.ID, tcpSvcs[0].ID())
+	assert.Equal(t, tcpID, tcpSvcs[1].ID())
+	assert.Equal(t, proxyx.HTTPID, httpSvcs[0].ID())
+	assert.Equal(t, httpID, httpSvcs[1].ID())
+
+	errs, err := svr.Serve(helloSvc, backend, tcpSvcs[0], tcpSvcs[1], httpSvcs[0], httpSvcs[1])
+	require.NoError(t, err)
+
+	for _, id := range []netx.ServiceID{proxyx.ID, tcpID} {
+		dialer, err := svr.Dialer(id)
+		require.NoError(t, err)
+
+		conn, err := dialer.Dial()
+		require.NoError(t, err)
+
+		data, err := io.ReadAll(conn)
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(data))
+		conn.Close()
+	}
+
+	for _, id := range []netx.ServiceID{proxyx.HTTPID, httpID} {
+		transport, err := httpx.LoadTransport(svr, httpx.WithTransportID(id))
+		require.NoError(t, err)
+
+		resp, err := (&http.Client{Transport: transport}).Get("localapp:///")
+		require.NoError(t, err)
+
+		data, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(data))
+		transport.CloseIdleConnections()
+	}
+
+	svr.Close(context.Background())
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+}
@@ -0,0 +1,136 @@
+package proxyx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+
+	"github.com/oligarch316/go-netx"
+	"github.com/oligarch316/go-netx/servicex/streamx"
+)
+
+type namespace struct{}
+
+func (n namespace) String() string { return "proxyx" }
+
+type instance struct{ name string }
+
+func (i instance) String() string { return fmt.Sprintf("%s:%s", ID, i.name) }
+
+// ID TODO.
+var ID netx.ServiceID = namespace{}
+
+// NewID TODO.
+func NewID(name string) netx.ServiceID { return instance{name: name} }
+
+// ServiceOption TODO.
+type ServiceOption func(*ServiceParams)
+
+// ServiceParams TODO.
+type ServiceParams struct {
+	ID             netx.ServiceID
+	SessionHandler SessionHandler
+	StreamOptions  []streamx.ServiceOption
+}
+
+// Service TODO.
+type Service struct {
+	*streamx.Service
+
+	id       netx.ServiceID
+	target   netx.Dialer
+	sessions *sessionTracker
+}
+
+// NewService TODO.
+func NewService(target netx.Dialer, opts ...ServiceOption) *Service {
+	params := ServiceParams{ID: ID}
+	for _, opt := range opts {
+		opt(&params)
+	}
+
+	res := &Service{
+		id:       params.ID,
+		target:   target,
+		sessions: newSessionTracker(params.SessionHandler),
+	}
+
+	res.Service = streamx.NewService(res.handle, params.StreamOptions...)
+	return res
+}
+
+// ID TODO.
+func (s *Service) ID() netx.ServiceID { return s.id }
+
+// Sessions TODO.
+func (s *Service) Sessions() []Session { return s.sessions.sessions() }
+
+// handle deliberately ignores cancellation of ctx once the target is dialed, so
+// that Close drains in-flight sessions until its own context expires.
+func (s *Service) handle(ctx context.Context, conn net.Conn) {
+	sess := s.sessions.start(conn.LocalAddr().String(), conn.RemoteAddr().String(), nil)
+
+	target, err := s.target.DialContext(ctx)
+	if err != nil {
+		s.sessions.end(sess, err)
+		return
+	}
+
+	defer target.Close()
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- proxyCopy(target, conn, sessionCounter{&sess.bytesIn})
+	}()
+
+	err = proxyCopy(conn, target, sessionCounter{&sess.bytesOut})
+	if inErr := <-done; err == nil {
+		err = inErr
+	}
+
+	s.sessions.end(sess, err)
+}
+
+type proxyCloseWriter interface{ CloseWrite() error }
+
+// proxyCopy copies src to dst until EOF, then propagates the EOF by way of a
+// half close if dst supports it. Without half close support dst is left open,
+// so that data still in flight in the other direction is delivered, and the
+// session ends once that direction completes as well. Any other error closes
+// both ends, aborting the other direction.
+func proxyCopy(dst, src net.Conn, counter sessionCounter) error {
+	_, err := io.Copy(dst, &proxyCountReader{Reader: src, counter: counter})
+
+	if err == nil {
+		if cw, ok := dst.(proxyCloseWriter); ok {
+			cw.CloseWrite()
+		}
+		return nil
+	}
+
+	dst.Close()
+	src.Close()
+
+	if proxyIsClosed(err) {
+		err = nil
+	}
+	return err
+}
+
+type proxyCountReader struct {
+	io.Reader
+	counter sessionCounter
+}
+
+func (pcr *proxyCountReader) Read(p []byte) (int, error) {
+	n, err := pcr.Reader.Read(p)
+	pcr.counter.add(n)
+	return n, err
+}
+
+func proxyIsClosed(err error) bool {
+	return errors.Is(err, net.ErrClosed) || errors.Is(err, io.ErrClosedPipe)
+}
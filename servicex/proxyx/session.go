@@ -0,0 +1,114 @@
+package proxyx
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Session TODO.
+type Session struct {
+	ID            uint64
+	Local, Remote string
+	Start, End    time.Time
+
+	// BytesIn counts bytes forwarded from the client to the target, BytesOut
+	// those forwarded from the target back to the client.
+	BytesIn, BytesOut int64
+
+	Err error
+}
+
+// SessionHandler TODO.
+type SessionHandler func(Session)
+
+type session struct {
+	// Accessed atomically, first for 64-bit alignment on 32-bit platforms
+	bytesIn, bytesOut int64
+
+	Session
+
+	// bytesOutFunc, if set, replaces bytesOut as the count of bytes forwarded
+	// back to the client
+	bytesOutFunc func() int64
+
+	// proxyErr is the error reported by the reverse proxy of HTTP sessions,
+	// set from the goroutine serving the request
+	proxyErr error
+}
+
+func (s *session) snapshot() Session {
+	res := s.Session
+	res.BytesIn = atomic.LoadInt64(&s.bytesIn)
+	res.BytesOut = atomic.LoadInt64(&s.bytesOut)
+
+	if s.bytesOutFunc != nil {
+		res.BytesOut = s.bytesOutFunc()
+	}
+
+	return res
+}
+
+type sessionCounter struct {
+	count *int64
+}
+
+func (sc sessionCounter) add(n int) { atomic.AddInt64(sc.count, int64(n)) }
+
+type sessionTracker struct {
+	// Accessed atomically, first for 64-bit alignment on 32-bit platforms
+	nextID uint64
+
+	handler SessionHandler
+
+	mu     sync.Mutex
+	active map[uint64]*session
+}
+
+func newSessionTracker(handler SessionHandler) *sessionTracker {
+	return &sessionTracker{handler: handler, active: make(map[uint64]*session)}
+}
+
+func (st *sessionTracker) start(local, remote string, bytesOutFunc func() int64) *session {
+	res := &session{
+		bytesOutFunc: bytesOutFunc,
+		Session: Session{
+			ID:     atomic.AddUint64(&st.nextID, 1),
+			Local:  local,
+			Remote: remote,
+			Start:  time.Now(),
+		},
+	}
+
+	st.mu.Lock()
+	st.active[res.ID] = res
+	st.mu.Unlock()
+
+	return res
+}
+
+func (st *sessionTracker) end(s *session, err error) {
+	st.mu.Lock()
+	delete(st.active, s.ID)
+	st.mu.Unlock()
+
+	res := s.snapshot()
+	res.End, res.Err = time.Now(), err
+
+	if st.handler != nil {
+		st.handler(res)
+	}
+}
+
+func (st *sessionTracker) sessions() []Session {
+	st.mu.Lock()
+	res := make([]Session, 0, len(st.active))
+	for _, s := range st.active {
+		res = append(res, s.snapshot())
+	}
+	st.mu.Unlock()
+
+	sort.Slice(res, func(i, j int) bool { return res[i].ID < res[j].ID })
+	return res
+}
@@ -3,6 +3,7 @@ package servicex
 import (
 	"context"
 	"net"
+	"sync"
 
 	"github.com/oligarch316/go-netx"
 )
@@ -32,3 +33,65 @@ func (r *Runner) Run() error { return r.service.Serve(r.listener) }
 
 // Close TODO.
 func (r *Runner) Close(ctx context.Context) error { return r.service.Close(ctx) }
+
+// PacketRunner TODO.
+type PacketRunner struct {
+	conns   []net.PacketConn
+	service netx.PacketService
+}
+
+// NewPacketRunner TODO.
+func NewPacketRunner(conns []net.PacketConn, svc netx.PacketService) *PacketRunner {
+	return &PacketRunner{
+		conns:   conns,
+		service: svc,
+	}
+}
+
+// ID TODO.
+func (pr PacketRunner) ID() netx.ServiceID { return pr.service.ID() }
+
+// Run TODO.
+func (pr *PacketRunner) Run() error {
+	var (
+		wg   sync.WaitGroup
+		errs = make(chan error, len(pr.conns))
+	)
+
+	for _, item := range pr.conns {
+		wg.Add(1)
+		go func(pc net.PacketConn) {
+			defer wg.Done()
+			errs <- pr.service.ServePacket(pc)
+		}(item)
+	}
+
+	go func() {
+		wg.Wait()
+		close(errs)
+	}()
+
+	var res error
+	for err := range errs {
+		if err != nil && res == nil {
+			// Unblock the remaining ServePacket calls so the error surfaces
+			res = err
+			pr.closeConns()
+		}
+	}
+
+	return res
+}
+
+// Close TODO.
+func (pr *PacketRunner) Close(ctx context.Context) error {
+	err := pr.service.Close(ctx)
+	pr.closeConns()
+	return err
+}
+
+func (pr *PacketRunner) closeConns() {
+	for _, pc := range pr.conns {
+		pc.Close()
+	}
+}
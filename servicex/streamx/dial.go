@@ -0,0 +1,29 @@
+package streamx
+
+import (
+	"github.com/oligarch316/go-netx"
+	"github.com/oligarch316/go-netx/serverx"
+)
+
+// DialerOption TODO.
+type DialerOption func(*DialerParams)
+
+// DialerParams TODO.
+type DialerParams struct {
+	// ServiceID selects the service dialed by LoadDialer.
+	ServiceID netx.ServiceID
+}
+
+// LoadDialer TODO.
+func LoadDialer(svr *serverx.Server, opts ...DialerOption) (netx.Dialer, error) {
+	params := DialerParams{ServiceID: ID}
+	for _, opt := range opts {
+		opt(&params)
+	}
+
+	dialer, err := svr.Dialer(params.ServiceID)
+	if err != nil {
+		return nil, err
+	}
+	return dialer, nil
+}
@@ -0,0 +1,58 @@
+package streamx
+
+import (
+	"context"
+	"net"
+
+	"github.com/oligarch316/go-netx"
+	"github.com/oligarch316/go-netx/listenerx/retry"
+	"github.com/oligarch316/go-netx/serverx"
+)
+
+// ----- Server Options
+
+// WithListeners TODO.
+func WithListeners(ls ...netx.Listener) serverx.Option {
+	return serverx.WithListeners(ID, ls...)
+}
+
+// WithDependencies TODO.
+func WithDependencies(deps ...netx.ServiceID) serverx.Option {
+	return serverx.WithDependencies(ID, deps...)
+}
+
+// WithIDListeners is WithListeners for the service given WithID, with an id
+// made by NewID.
+func WithIDListeners(id netx.ServiceID, ls ...netx.Listener) serverx.Option {
+	return serverx.WithListeners(id, ls...)
+}
+
+// WithIDDependencies is WithDependencies for the service given WithID, with an
+// id made by NewID.
+func WithIDDependencies(id netx.ServiceID, deps ...netx.ServiceID) serverx.Option {
+	return serverx.WithDependencies(id, deps...)
+}
+
+// ----- Service Options
+
+// WithID TODO.
+func WithID(id netx.ServiceID) ServiceOption {
+	return func(p *ServiceParams) { p.ID = id }
+}
+
+// WithAcceptRetryDelay TODO.
+func WithAcceptRetryDelay(delayFunc retry.DelayFunc) ServiceOption {
+	return func(p *ServiceParams) { p.AcceptRetryDelay = delayFunc }
+}
+
+// WithConnContext TODO.
+func WithConnContext(f func(context.Context, net.Conn) context.Context) ServiceOption {
+	return func(p *ServiceParams) { p.ConnContext = f }
+}
+
+// ----- Dialer Options
+
+// WithDialerID TODO.
+func WithDialerID(id netx.ServiceID) DialerOption {
+	return func(p *DialerParams) { p.ServiceID = id }
+}
@@ -0,0 +1,233 @@
+package streamx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/oligarch316/go-netx"
+	"github.com/oligarch316/go-netx/listenerx/retry"
+)
+
+type namespace struct{}
+
+func (n namespace) String() string { return "streamx" }
+
+type instance struct{ name string }
+
+func (i instance) String() string { return fmt.Sprintf("%s:%s", ID, i.name) }
+
+// ID TODO.
+var ID netx.ServiceID = namespace{}
+
+// NewID TODO.
+func NewID(name string) netx.ServiceID { return instance{name: name} }
+
+var errServiceClosed = errors.New("streamx: service closed")
+
+// Handler TODO.
+type Handler func(context.Context, net.Conn)
+
+// ServiceOption TODO.
+type ServiceOption func(*ServiceParams)
+
+// ServiceParams TODO.
+type ServiceParams struct {
+	ID netx.ServiceID
+
+	// AcceptRetryDelay paces retries of temporary accept errors, which would
+	// otherwise end Serve.
+	AcceptRetryDelay retry.DelayFunc
+
+	// ConnContext, if set, derives the context passed to the handler for each
+	// connection from the service context.
+	ConnContext func(context.Context, net.Conn) context.Context
+}
+
+// Service TODO.
+type Service struct {
+	id      netx.ServiceID
+	params  ServiceParams
+	handler Handler
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu        sync.Mutex
+	closed    bool
+	listeners map[net.Listener]struct{}
+	conns     map[net.Conn]struct{}
+}
+
+// NewService TODO.
+func NewService(handler Handler, opts ...ServiceOption) *Service {
+	params := ServiceParams{
+		ID:               ID,
+		AcceptRetryDelay: retry.DelayFuncExponential(5*time.Millisecond, 1*time.Second, 2),
+	}
+
+	for _, opt := range opts {
+		opt(&params)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Service{
+		id:        params.ID,
+		params:    params,
+		handler:   handler,
+		ctx:       ctx,
+		cancel:    cancel,
+		listeners: make(map[net.Listener]struct{}),
+		conns:     make(map[net.Conn]struct{}),
+	}
+}
+
+// ID TODO.
+func (s *Service) ID() netx.ServiceID { return s.id }
+
+// ActiveConns TODO.
+func (s *Service) ActiveConns() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.conns)
+}
+
+func (s *Service) trackListener(l net.Listener, add bool) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !add {
+		delete(s.listeners, l)
+		return true
+	}
+
+	if s.closed {
+		return false
+	}
+
+	s.listeners[l] = struct{}{}
+	return true
+}
+
+func (s *Service) trackConn(conn net.Conn, add bool) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !add {
+		delete(s.conns, conn)
+		s.wg.Done()
+		return true
+	}
+
+	if s.closed {
+		return false
+	}
+
+	s.conns[conn] = struct{}{}
+	s.wg.Add(1)
+	return true
+}
+
+func (s *Service) isClosed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closed
+}
+
+// Serve TODO.
+func (s *Service) Serve(l net.Listener) error {
+	if !s.trackListener(l, true) {
+		return errServiceClosed
+	}
+
+	defer s.trackListener(l, false)
+
+	delay := retry.NewDelay(s.params.AcceptRetryDelay)
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if s.isClosed() {
+				return nil
+			}
+
+			if ne, ok := err.(net.Error); !ok || !ne.Temporary() {
+				return err
+			}
+
+			// Temporary error => delay and retry
+			_, delayDuration := delay.Next()
+
+			select {
+			case <-time.After(delayDuration):
+				continue
+			case <-s.ctx.Done():
+				return nil
+			}
+		}
+
+		delay.Reset()
+
+		if !s.trackConn(conn, true) {
+			conn.Close()
+			return nil
+		}
+
+		go s.handle(conn)
+	}
+}
+
+func (s *Service) handle(conn net.Conn) {
+	defer s.trackConn(conn, false)
+	defer conn.Close()
+
+	ctx := s.ctx
+	if s.params.ConnContext != nil {
+		ctx = s.params.ConnContext(ctx, conn)
+	}
+
+	s.handler(ctx, conn)
+}
+
+// Close TODO.
+//
+// Should ctx expire before all handlers return, their connections are closed
+// and the context's error returned.
+func (s *Service) Close(ctx context.Context) error {
+	// Stop accepting
+	s.mu.Lock()
+	s.closed = true
+	for l := range s.listeners {
+		l.Close()
+	}
+	s.mu.Unlock()
+
+	// Signal handlers to finish up
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+	}
+
+	// Handlers failed to finish in time, force their connections closed
+	s.mu.Lock()
+	for conn := range s.conns {
+		conn.Close()
+	}
+	s.mu.Unlock()
+
+	return ctx.Err()
+}
@@ -0,0 +1,220 @@
+package streamx_test
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/oligarch316/go-netx"
+	"github.com/oligarch316/go-netx/listenerx"
+	"github.com/oligarch316/go-netx/listenerx/fault"
+	"github.com/oligarch316/go-netx/listenerx/retry"
+	"github.com/oligarch316/go-netx/serverx"
+	"github.com/oligarch316/go-netx/servicex/streamx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serve(t *testing.T, svc *streamx.Service) (*serverx.Server, <-chan error) {
+	t.Helper()
+
+	svr, err := serverx.NewServer(streamx.WithListeners(listenerx.NewInternal(0)))
+	require.NoError(t, err)
+
+	errs, err := svr.Serve(svc)
+	require.NoError(t, err)
+
+	return svr, errs
+}
+
+func TestServiceEcho(t *testing.T) {
+	svc := streamx.NewService(func(ctx context.Context, conn net.Conn) {
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+			conn.Write(append(scanner.Bytes(), '\n'))
+		}
+	})
+
+	svr, errs := serve(t, svc)
+
+	dialer, err := streamx.LoadDialer(svr)
+	require.NoError(t, err)
+
+	conn, err := dialer.Dial()
+	require.NoError(t, err)
+
+	_, err = conn.Write([]byte("hello\n"))
+	require.NoError(t, err)
+
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "hello\n", line)
+	assert.Equal(t, 1, svc.ActiveConns())
+
+	require.NoError(t, conn.Close())
+	require.Eventually(t, func() bool { return svc.ActiveConns() == 0 }, time.Second, time.Millisecond)
+
+	svr.Close(context.Background())
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+}
+
+func TestServiceClose(t *testing.T) {
+	subtests := []struct {
+		name      string
+		handler   streamx.Handler
+		cancelled bool
+	}{
+		{
+			name:      "handler honors context",
+			handler:   func(ctx context.Context, _ net.Conn) { <-ctx.Done() },
+			cancelled: false,
+		},
+		{
+			name: "handler ignores context",
+			handler: func(_ context.Context, conn net.Conn) {
+				// Blocks until the connection is forcibly closed
+				conn.Read(make([]byte, 1))
+			},
+			cancelled: true,
+		},
+	}
+
+	for _, item := range subtests {
+		subtest := item
+
+		t.Run(subtest.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				svc       = streamx.NewService(subtest.handler)
+				svr, errs = serve(t, svc)
+				closeCtx  = context.Background()
+			)
+
+			dialer, err := streamx.LoadDialer(svr)
+			require.NoError(t, err)
+
+			conn, err := dialer.Dial()
+			require.NoError(t, err)
+
+			defer conn.Close()
+			require.Eventually(t, func() bool { return svc.ActiveConns() == 1 }, time.Second, time.Millisecond)
+
+			if subtest.cancelled {
+				ctx, cancel := context.WithTimeout(closeCtx, 10*time.Millisecond)
+				defer cancel()
+				closeCtx = ctx
+			}
+
+			svr.Close(closeCtx)
+			require.Eventually(t, func() bool { return svc.ActiveConns() == 0 }, time.Second, time.Millisecond)
+
+			var closeErr error
+			for err := range errs {
+				if closeErr == nil {
+					closeErr = err
+				}
+			}
+
+			if subtest.cancelled {
+				assert.ErrorIs(t, closeErr, context.DeadlineExceeded)
+			} else {
+				assert.NoError(t, closeErr)
+			}
+
+			_, err = dialer.Dial()
+			assert.Error(t, err, "expected dial after close to fail")
+		})
+	}
+}
+
+func TestServiceMultipleInstances(t *testing.T) {
+	var (
+		publicID   = streamx.NewID("public")
+		internalID = streamx.NewID("internal")
+	)
+
+	assert.Equal(t, "streamx:public", publicID.String())
+	assert.NotEqual(t, streamx.ID, streamx.NewID(streamx.ID.String()))
+
+	svr, err := serverx.NewServer(
+		streamx.WithIDListeners(publicID, listenerx.NewInternal(0)),
+		streamx.WithIDListeners(internalID, listenerx.NewInternal(0)),
+		streamx.WithIDDependencies(publicID, internalID),
+	)
+	require.NoError(t, err)
+
+	newService := func(id netx.ServiceID) *streamx.Service {
+		handler := func(_ context.Context, conn net.Conn) { io.WriteString(conn, id.String()) }
+		return streamx.NewService(handler, streamx.WithID(id))
+	}
+
+	var (
+		publicSvc   = newService(publicID)
+		internalSvc = newService(internalID)
+	)
+
+	assert.Equal(t, publicID, publicSvc.ID())
+	assert.Equal(t, internalID, internalSvc.ID())
+
+	errs, err := svr.Serve(publicSvc, internalSvc)
+	require.NoError(t, err)
+
+	for _, id := range []netx.ServiceID{publicID, internalID} {
+		dialer, err := streamx.LoadDialer(svr, streamx.WithDialerID(id))
+		require.NoError(t, err)
+
+		conn, err := dialer.Dial()
+		require.NoError(t, err)
+
+		data, err := io.ReadAll(conn)
+		require.NoError(t, err)
+		assert.Equal(t, id.String(), string(data))
+		conn.Close()
+	}
+
+	_, err = streamx.LoadDialer(svr)
+	assert.Error(t, err, "expected no service under the default id")
+
+	svr.Close(context.Background())
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+}
+
+func TestServiceAcceptErrorsRetried(t *testing.T) {
+	var (
+		source = fault.NewListener(listenerx.NewInternal(0), fault.WithSeed(1), fault.WithAcceptErrorRate(0.5))
+		svc    = streamx.NewService(
+			func(_ context.Context, conn net.Conn) { io.Copy(conn, conn) },
+			streamx.WithAcceptRetryDelay(retry.DelayFuncConstant(time.Millisecond)),
+		)
+		served = make(chan error, 1)
+	)
+
+	go func() { served <- svc.Serve(source) }()
+
+	// Injected temporary accept errors must neither end Serve nor lose
+	// connections
+	for i := 0; i < 10; i++ {
+		conn, err := source.Dial()
+		require.NoError(t, err)
+
+		_, err = conn.Write([]byte("ping"))
+		require.NoError(t, err)
+
+		buf := make([]byte, 4)
+		_, err = io.ReadFull(conn, buf)
+		require.NoError(t, err)
+		assert.Equal(t, "ping", string(buf))
+		conn.Close()
+	}
+
+	require.NoError(t, svc.Close(context.Background()))
+	assert.NoError(t, <-served)
+}
@@ -0,0 +1,67 @@
+package tracex
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// PropagationKey is the header and metadata key carrying span contexts
+// between processes, formatted as "<trace id>-<span id>".
+const PropagationKey = "netx-trace"
+
+// Carrier TODO.
+type Carrier interface {
+	Get(key string) string
+	Set(key, value string)
+}
+
+// HeaderCarrier TODO.
+type HeaderCarrier http.Header
+
+// Get TODO.
+func (hc HeaderCarrier) Get(key string) string { return http.Header(hc).Get(key) }
+
+// Set TODO.
+func (hc HeaderCarrier) Set(key, value string) { http.Header(hc).Set(key, value) }
+
+// MetadataCarrier TODO.
+type MetadataCarrier metadata.MD
+
+// Get TODO.
+func (mc MetadataCarrier) Get(key string) string {
+	if vals := metadata.MD(mc).Get(key); len(vals) > 0 {
+		return vals[0]
+	}
+	return ""
+}
+
+// Set TODO.
+func (mc MetadataCarrier) Set(key, value string) { metadata.MD(mc).Set(key, value) }
+
+// Inject writes the span context of ctx, if any, to carrier.
+func Inject(ctx context.Context, carrier Carrier) {
+	if sc := SpanContextFromContext(ctx); sc.IsValid() {
+		carrier.Set(PropagationKey, sc.TraceID+"-"+sc.SpanID)
+	}
+}
+
+// Extract returns ctx with the remote span context read from carrier, if any,
+// to be used as the parent of spans started from it.
+func Extract(ctx context.Context, carrier Carrier) context.Context {
+	val := carrier.Get(PropagationKey)
+
+	idx := strings.IndexByte(val, '-')
+	if idx < 0 {
+		return ctx
+	}
+
+	sc := SpanContext{TraceID: val[:idx], SpanID: val[idx+1:]}
+	if !sc.IsValid() {
+		return ctx
+	}
+
+	return ContextWithRemoteSpanContext(ctx, sc)
+}
@@ -0,0 +1,118 @@
+package tracex
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RecordedSpan TODO.
+type RecordedSpan struct {
+	SpanContext
+	Name       string
+	ParentID   string
+	Attributes []Attribute
+	Errors     []error
+	Start, End time.Time
+}
+
+// Attribute returns the last value set for key.
+func (rs RecordedSpan) Attribute(key string) (interface{}, bool) {
+	for i := len(rs.Attributes) - 1; i >= 0; i-- {
+		if rs.Attributes[i].Key == key {
+			return rs.Attributes[i].Value, true
+		}
+	}
+	return nil, false
+}
+
+// Recorder is an in-memory Tracer keeping every ended span, intended for
+// tests.
+type Recorder struct {
+	mu    sync.Mutex
+	spans []RecordedSpan
+}
+
+// NewRecorder TODO.
+func NewRecorder() *Recorder { return new(Recorder) }
+
+// Start TODO.
+func (r *Recorder) Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	var (
+		parent = SpanContextFromContext(ctx)
+		res    = &recorderSpan{recorder: r}
+	)
+
+	res.data = RecordedSpan{
+		SpanContext: SpanContext{TraceID: parent.TraceID, SpanID: newSpanID()},
+		Name:        name,
+		ParentID:    parent.SpanID,
+		Attributes:  append([]Attribute(nil), attrs...),
+		Start:       time.Now(),
+	}
+
+	if !parent.IsValid() {
+		res.data.TraceID, res.data.ParentID = newTraceID(), ""
+	}
+
+	return ContextWithSpan(ctx, res), res
+}
+
+// Spans returns the ended spans in the order they ended.
+func (r *Recorder) Spans() []RecordedSpan {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]RecordedSpan(nil), r.spans...)
+}
+
+// Reset TODO.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.spans = nil
+}
+
+type recorderSpan struct {
+	recorder *Recorder
+
+	mu    sync.Mutex
+	data  RecordedSpan
+	ended bool
+}
+
+func (rs *recorderSpan) SpanContext() SpanContext { return rs.data.SpanContext }
+
+func (rs *recorderSpan) SetAttributes(attrs ...Attribute) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if !rs.ended {
+		rs.data.Attributes = append(rs.data.Attributes, attrs...)
+	}
+}
+
+func (rs *recorderSpan) RecordError(err error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if !rs.ended && err != nil {
+		rs.data.Errors = append(rs.data.Errors, err)
+	}
+}
+
+func (rs *recorderSpan) End() {
+	rs.mu.Lock()
+	if rs.ended {
+		rs.mu.Unlock()
+		return
+	}
+
+	rs.ended = true
+	rs.data.End = time.Now()
+	data := rs.data
+	rs.mu.Unlock()
+
+	rs.recorder.mu.Lock()
+	rs.recorder.spans = append(rs.recorder.spans, data)
+	rs.recorder.mu.Unlock()
+}
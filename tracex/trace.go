@@ -0,0 +1,105 @@
+package tracex
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// Attribute TODO.
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// Attr TODO.
+func Attr(key string, value interface{}) Attribute { return Attribute{Key: key, Value: value} }
+
+// SpanContext TODO.
+type SpanContext struct {
+	TraceID, SpanID string
+}
+
+// IsValid TODO.
+func (sc SpanContext) IsValid() bool { return sc.TraceID != "" && sc.SpanID != "" }
+
+// Span TODO.
+type Span interface {
+	SpanContext() SpanContext
+	SetAttributes(attrs ...Attribute)
+	RecordError(err error)
+	End()
+}
+
+// Tracer TODO.
+type Tracer interface {
+	Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span)
+}
+
+// ----- Context
+
+type (
+	spanKey   struct{}
+	remoteKey struct{}
+)
+
+// ContextWithSpan TODO.
+func ContextWithSpan(ctx context.Context, span Span) context.Context {
+	return context.WithValue(ctx, spanKey{}, span)
+}
+
+// SpanFromContext returns the active span of ctx, or a no-op span carrying
+// any remote parent if there is none.
+func SpanFromContext(ctx context.Context) Span {
+	if span, ok := ctx.Value(spanKey{}).(Span); ok {
+		return span
+	}
+
+	sc, _ := ctx.Value(remoteKey{}).(SpanContext)
+	return noopSpan{sc: sc}
+}
+
+// ContextWithRemoteSpanContext TODO.
+func ContextWithRemoteSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, remoteKey{}, sc)
+}
+
+// SpanContextFromContext returns the span context of the active span of ctx,
+// falling back to a remote parent extracted from a carrier.
+func SpanContextFromContext(ctx context.Context) SpanContext {
+	return SpanFromContext(ctx).SpanContext()
+}
+
+// ----- Noop
+
+// Noop TODO.
+var Noop Tracer = noopTracer{}
+
+type noopTracer struct{}
+
+// Start returns a no-op span carrying the parent span context, never the parent
+// span itself, which the caller would otherwise end or annotate.
+func (noopTracer) Start(ctx context.Context, _ string, _ ...Attribute) (context.Context, Span) {
+	return ctx, noopSpan{sc: SpanContextFromContext(ctx)}
+}
+
+type noopSpan struct{ sc SpanContext }
+
+func (ns noopSpan) SpanContext() SpanContext { return ns.sc }
+func (noopSpan) SetAttributes(...Attribute)  {}
+func (noopSpan) RecordError(error)           {}
+func (noopSpan) End()                        {}
+
+// ----- IDs
+
+func newID(size int) string {
+	buf := make([]byte, size)
+
+	// The entropy source failing leaves a zero ID, which is still usable for
+	// correlation within a single process
+	rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+func newTraceID() string { return newID(16) }
+func newSpanID() string  { return newID(8) }
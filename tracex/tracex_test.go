@@ -0,0 +1,111 @@
+package tracex_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/oligarch316/go-netx/tracex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestRecorderParenting(t *testing.T) {
+	rec := tracex.NewRecorder()
+
+	ctx, root := rec.Start(context.Background(), "root", tracex.Attr("a", 1))
+	_, child := rec.Start(ctx, "child")
+
+	child.RecordError(errors.New("boom"))
+	child.End()
+	root.SetAttributes(tracex.Attr("a", 2))
+	root.End()
+	root.End()
+
+	spans := rec.Spans()
+	require.Len(t, spans, 2)
+
+	assert.Equal(t, "child", spans[0].Name)
+	assert.Equal(t, root.SpanContext().TraceID, spans[0].TraceID)
+	assert.Equal(t, root.SpanContext().SpanID, spans[0].ParentID)
+	assert.Len(t, spans[0].Errors, 1)
+
+	assert.Equal(t, "root", spans[1].Name)
+	assert.Empty(t, spans[1].ParentID)
+
+	val, ok := spans[1].Attribute("a")
+	assert.True(t, ok)
+	assert.Equal(t, 2, val)
+
+	rec.Reset()
+	assert.Empty(t, rec.Spans())
+}
+
+func TestNoopParent(t *testing.T) {
+	rec := tracex.NewRecorder()
+
+	ctx, parent := rec.Start(context.Background(), "parent")
+
+	noopCtx, span := tracex.Noop.Start(ctx, "noop")
+	assert.Equal(t, parent.SpanContext(), span.SpanContext())
+	assert.Equal(t, parent.SpanContext(), tracex.SpanContextFromContext(noopCtx))
+
+	// Ending or annotating the no-op span leaves the parent untouched
+	span.SetAttributes(tracex.Attr("a", 1))
+	span.RecordError(errors.New("boom"))
+	span.End()
+	assert.Empty(t, rec.Spans())
+
+	parent.End()
+
+	spans := rec.Spans()
+	require.Len(t, spans, 1)
+	assert.Empty(t, spans[0].Attributes)
+	assert.Empty(t, spans[0].Errors)
+}
+
+func TestPropagation(t *testing.T) {
+	subtests := []struct {
+		name    string
+		carrier tracex.Carrier
+	}{
+		{name: "header", carrier: tracex.HeaderCarrier(http.Header{})},
+		{name: "metadata", carrier: tracex.MetadataCarrier(metadata.MD{})},
+	}
+
+	for _, subtest := range subtests {
+		subtest := subtest
+
+		t.Run(subtest.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := tracex.NewRecorder()
+			ctx, span := rec.Start(context.Background(), "client")
+			tracex.Inject(ctx, subtest.carrier)
+
+			// A no-op tracer in between must not break propagation
+			remoteCtx, _ := tracex.Noop.Start(tracex.Extract(context.Background(), subtest.carrier), "noop")
+			assert.Equal(t, span.SpanContext(), tracex.SpanContextFromContext(remoteCtx))
+
+			_, server := rec.Start(remoteCtx, "server")
+			server.End()
+
+			spans := rec.Spans()
+			require.Len(t, spans, 1)
+			assert.Equal(t, span.SpanContext().TraceID, spans[0].TraceID)
+			assert.Equal(t, span.SpanContext().SpanID, spans[0].ParentID)
+		})
+	}
+}
+
+func TestExtractInvalid(t *testing.T) {
+	for _, val := range []string{"", "nodash", "-span", "trace-"} {
+		header := http.Header{}
+		header.Set(tracex.PropagationKey, val)
+
+		ctx := tracex.Extract(context.Background(), tracex.HeaderCarrier(header))
+		assert.False(t, tracex.SpanContextFromContext(ctx).IsValid(), val)
+	}
+}